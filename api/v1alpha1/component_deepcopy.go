@@ -0,0 +1,60 @@
+package v1alpha1
+
+// Component is excluded from deepcopy generation: the generator cannot copy the
+// free-form Config, and a shallow copy would share nested config values between
+// cached objects.
+
+// DeepCopyInto copies the receiver into out, including every nested map and
+// slice of Config, so cached objects never share config values
+func (in *Component) DeepCopyInto(out *Component) {
+	*out = *in
+	if in.Config != nil {
+		out.Config = deepCopyConfig(in.Config)
+	}
+}
+
+// DeepCopy creates a new Component copying the receiver
+func (in *Component) DeepCopy() *Component {
+	if in == nil {
+		return nil
+	}
+	out := new(Component)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func deepCopyConfig(in map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(in))
+	for key, val := range in {
+		out[key] = deepCopyConfigValue(val)
+	}
+	return out
+}
+
+// deepCopyConfigValue copies JSON-like values. Unlike runtime.DeepCopyJSONValue it
+// accepts any scalar, since configs built in Go may hold ints or other plain values.
+func deepCopyConfigValue(in interface{}) interface{} {
+	switch in := in.(type) {
+	case map[string]interface{}:
+		if in == nil {
+			return in
+		}
+		return deepCopyConfig(in)
+	case []interface{}:
+		if in == nil {
+			return in
+		}
+		out := make([]interface{}, len(in))
+		for i, val := range in {
+			out[i] = deepCopyConfigValue(val)
+		}
+		return out
+	case []string:
+		if in == nil {
+			return in
+		}
+		return append([]string(nil), in...)
+	default:
+		return in
+	}
+}
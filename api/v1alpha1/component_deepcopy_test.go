@@ -0,0 +1,44 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+)
+
+func TestFrontendPageDeepCopyCopiesNestedConfig(t *testing.T) {
+	page := &FrontendPage{
+		Spec: FrontendPageSpec{
+			Components: []Component{{
+				Name: "metrics",
+				Type: "table",
+				Config: map[string]interface{}{
+					"columns":  []interface{}{"Name", "Value"},
+					"style":    map[string]interface{}{"striped": true},
+					"widths":   []string{"10rem"},
+					"pageSize": 10,
+				},
+			}},
+		},
+	}
+	copied := page.DeepCopy()
+	if !apiequality.Semantic.DeepEqual(page, copied) {
+		t.Fatalf("expected copy to equal the original")
+	}
+
+	config := copied.Spec.Components[0].Config
+	config["columns"].([]interface{})[0] = "Key"
+	config["style"].(map[string]interface{})["striped"] = false
+	config["widths"].([]string)[0] = "20rem"
+
+	original := page.Spec.Components[0].Config
+	if original["columns"].([]interface{})[0] != "Name" {
+		t.Errorf("nested slice is shared with the copy")
+	}
+	if original["style"].(map[string]interface{})["striped"] != true {
+		t.Errorf("nested map is shared with the copy")
+	}
+	if original["widths"].([]string)[0] != "10rem" {
+		t.Errorf("nested string slice is shared with the copy")
+	}
+}
@@ -0,0 +1,239 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1beta1"
+)
+
+// v1beta1FieldsAnnotation keeps the v1beta1 fields v1alpha1 cannot represent, so an
+// object read and written back by a v1alpha1 client does not lose them
+const v1beta1FieldsAnnotation = "frontend.thegostev.com/v1beta1-fields"
+
+// v1beta1Fields is the content of the v1beta1FieldsAnnotation
+type v1beta1Fields struct {
+	// Theme is the full theme; it is only restored while the theme name is unchanged
+	Theme *v1beta1.Theme `json:"theme,omitempty"`
+
+	Layout *v1beta1.Layout `json:"layout,omitempty"`
+
+	// Order maps component names to their order hint
+	Order map[string]int32 `json:"order,omitempty"`
+}
+
+var _ conversion.Convertible = &FrontendPage{}
+
+// ConvertTo converts this FrontendPage to the hub version (v1beta1)
+func (src *FrontendPage) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1beta1.FrontendPage)
+	if !ok {
+		return fmt.Errorf("expected a v1beta1 FrontendPage but got %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	var fields v1beta1Fields
+	if raw, ok := dst.Annotations[v1beta1FieldsAnnotation]; ok {
+		// A malformed annotation only means the v1beta1-only fields are lost
+		_ = json.Unmarshal([]byte(raw), &fields)
+		delete(dst.Annotations, v1beta1FieldsAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	dst.Spec = v1beta1.FrontendPageSpec{
+		Title:    src.Spec.Title,
+		Template: src.Spec.Template,
+		Theme:    v1beta1.Theme{Name: src.Spec.Theme},
+	}
+	if fields.Theme != nil && fields.Theme.Name == src.Spec.Theme {
+		dst.Spec.Theme = *fields.Theme
+	}
+	if fields.Layout != nil {
+		dst.Spec.Layout = *fields.Layout
+	}
+	if src.Spec.Components != nil {
+		dst.Spec.Components = make([]v1beta1.Component, len(src.Spec.Components))
+		for i, component := range src.Spec.Components {
+			converted, err := convertComponentToHub(component)
+			if err != nil {
+				return fmt.Errorf("failed to convert component %q: %w", component.Name, err)
+			}
+			converted.Order = fields.Order[component.Name]
+			dst.Spec.Components[i] = converted
+		}
+	}
+
+	status := src.Status.DeepCopy()
+	dst.Status = v1beta1.FrontendPageStatus{
+		Phase:              status.Phase,
+		Message:            status.Message,
+		ObservedGeneration: status.ObservedGeneration,
+		Conditions:         status.Conditions,
+		URL:                status.URL,
+		ComponentCount:     status.ComponentCount,
+		LastUpdated:        status.LastUpdated,
+	}
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1beta1) to this version
+func (dst *FrontendPage) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1beta1.FrontendPage)
+	if !ok {
+		return fmt.Errorf("expected a v1beta1 FrontendPage but got %T", srcRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	var fields v1beta1Fields
+
+	dst.Spec = FrontendPageSpec{
+		Title:    src.Spec.Title,
+		Template: src.Spec.Template,
+		Theme:    src.Spec.Theme.Name,
+	}
+	if src.Spec.Theme != (v1beta1.Theme{Name: src.Spec.Theme.Name}) {
+		theme := src.Spec.Theme
+		fields.Theme = &theme
+	}
+	if src.Spec.Layout != (v1beta1.Layout{}) {
+		layout := src.Spec.Layout
+		fields.Layout = &layout
+	}
+	if src.Spec.Components != nil {
+		dst.Spec.Components = make([]Component, len(src.Spec.Components))
+		for i, component := range src.Spec.Components {
+			converted, err := convertComponentFromHub(component)
+			if err != nil {
+				return fmt.Errorf("failed to convert component %q: %w", component.Name, err)
+			}
+			dst.Spec.Components[i] = converted
+			if component.Order != 0 {
+				if fields.Order == nil {
+					fields.Order = make(map[string]int32)
+				}
+				fields.Order[component.Name] = component.Order
+			}
+		}
+	}
+
+	delete(dst.Annotations, v1beta1FieldsAnnotation)
+	if fields.Theme != nil || fields.Layout != nil || fields.Order != nil {
+		raw, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = make(map[string]string)
+		}
+		dst.Annotations[v1beta1FieldsAnnotation] = string(raw)
+	}
+
+	status := src.Status.DeepCopy()
+	dst.Status = FrontendPageStatus{
+		Phase:              status.Phase,
+		Message:            status.Message,
+		ObservedGeneration: status.ObservedGeneration,
+		Conditions:         status.Conditions,
+		URL:                status.URL,
+		ComponentCount:     status.ComponentCount,
+		LastUpdated:        status.LastUpdated,
+	}
+	return nil
+}
+
+// convertComponentToHub moves a free-form config into the typed config of built-in
+// component types. Configs the typed config cannot represent exactly stay in Config.
+func convertComponentToHub(in Component) (v1beta1.Component, error) {
+	out := v1beta1.Component{Name: in.Name, Type: in.Type}
+	if in.Config == nil {
+		return out, nil
+	}
+	raw, err := json.Marshal(in.Config)
+	if err != nil {
+		return out, err
+	}
+
+	switch in.Type {
+	case "table":
+		typed := &v1beta1.TableConfig{}
+		if decodeExact(raw, typed) {
+			out.Table = typed
+			return out, nil
+		}
+	case "chart":
+		typed := &v1beta1.ChartConfig{}
+		if decodeExact(raw, typed) {
+			out.Chart = typed
+			return out, nil
+		}
+	case "button":
+		typed := &v1beta1.ButtonConfig{}
+		if decodeExact(raw, typed) {
+			out.Button = typed
+			return out, nil
+		}
+	}
+	out.Config = &runtime.RawExtension{Raw: raw}
+	return out, nil
+}
+
+// convertComponentFromHub flattens the config matching the component type into a
+// free-form config
+func convertComponentFromHub(in v1beta1.Component) (Component, error) {
+	out := Component{Name: in.Name, Type: in.Type}
+
+	var typed interface{}
+	switch {
+	case in.Type == "table" && in.Table != nil:
+		typed = in.Table
+	case in.Type == "chart" && in.Chart != nil:
+		typed = in.Chart
+	case in.Type == "button" && in.Button != nil:
+		typed = in.Button
+	}
+
+	var raw []byte
+	if typed != nil {
+		var err error
+		if raw, err = json.Marshal(typed); err != nil {
+			return out, err
+		}
+	} else if in.Config != nil && in.Config.Raw != nil {
+		raw = in.Config.Raw
+	}
+	if raw == nil {
+		return out, nil
+	}
+	// utiljson decodes integers as int64, like the API machinery does for v1alpha1 objects
+	if err := utiljson.Unmarshal(raw, &out.Config); err != nil {
+		return out, fmt.Errorf("config is not a JSON object: %w", err)
+	}
+	return out, nil
+}
+
+// decodeExact decodes raw into typed and reports whether typed represents raw
+// exactly, i.e. no unknown, mistyped or explicitly null fields were dropped
+func decodeExact(raw []byte, typed interface{}) bool {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(typed); err != nil {
+		return false
+	}
+	encoded, err := json.Marshal(typed)
+	if err != nil {
+		return false
+	}
+	var want, got interface{}
+	if json.Unmarshal(raw, &want) != nil || json.Unmarshal(encoded, &got) != nil {
+		return false
+	}
+	return reflect.DeepEqual(want, got)
+}
@@ -0,0 +1,237 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	fuzz "github.com/google/gofuzz"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1beta1"
+)
+
+const fuzzIterations = 1000
+
+var componentTypes = []string{"table", "chart", "button", "carousel"}
+
+// commonFuzzFuncs keep metadata and status to values that survive JSON, as they would on the wire.
+// TypeMeta is left empty; it is set by the conversion webhook, not the conversion functions.
+func commonFuzzFuncs() []interface{} {
+	return []interface{}{
+		func(m *metav1.TypeMeta, c fuzz.Continue) {},
+		func(m *metav1.ObjectMeta, c fuzz.Continue) {
+			c.Fuzz(&m.Name)
+			c.Fuzz(&m.Namespace)
+			c.Fuzz(&m.Labels)
+			c.Fuzz(&m.Annotations)
+			c.Fuzz(&m.ResourceVersion)
+			c.Fuzz(&m.Generation)
+		},
+		func(t *metav1.Time, c fuzz.Continue) {
+			*t = metav1.NewTime(time.Unix(c.Int63n(1<<32), 0))
+		},
+	}
+}
+
+// fuzzConfig returns a JSON object the way the API machinery decodes one
+func fuzzConfig(c fuzz.Continue, depth int) map[string]interface{} {
+	config := make(map[string]interface{})
+	for i := c.Intn(4); i > 0; i-- {
+		var value interface{}
+		switch n := c.Intn(6); {
+		case n == 0:
+			value = c.RandString()
+		case n == 1:
+			value = c.RandBool()
+		case n == 2:
+			value = c.Int63()
+		case n == 3:
+			value = []interface{}{c.RandString(), c.Int63()}
+		case n == 4 && depth < 2:
+			value = fuzzConfig(c, depth+1)
+		}
+		config[c.RandString()] = value
+	}
+	return config
+}
+
+func spokeFuzzer() *fuzz.Fuzzer {
+	return fuzz.New().NilChance(0.2).NumElements(0, 4).Funcs(commonFuzzFuncs()...).Funcs(
+		func(comp *Component, c fuzz.Continue) {
+			c.Fuzz(&comp.Name)
+			comp.Type = componentTypes[c.Intn(len(componentTypes))]
+			switch c.Intn(4) {
+			case 0:
+				comp.Config = nil
+			case 1:
+				// A config the typed v1beta1 config represents exactly
+				comp.Config = map[string]interface{}{
+					"columns":  []interface{}{c.RandString()},
+					"pageSize": c.Int63n(100),
+					"type":     "bar",
+					"actions":  []interface{}{c.RandString()},
+				}
+				switch comp.Type {
+				case "table":
+					delete(comp.Config, "type")
+					delete(comp.Config, "actions")
+				case "chart":
+					comp.Config = map[string]interface{}{"type": "bar", "title": c.RandString()}
+				case "button":
+					comp.Config = map[string]interface{}{"actions": []interface{}{c.RandString()}}
+				}
+			default:
+				comp.Config = fuzzConfig(c, 0)
+			}
+		},
+	)
+}
+
+func hubFuzzer() *fuzz.Fuzzer {
+	return fuzz.New().NilChance(0.2).NumElements(0, 4).Funcs(commonFuzzFuncs()...).Funcs(
+		func(spec *v1beta1.FrontendPageSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(spec)
+			// Component names are unique in a valid spec
+			for i := range spec.Components {
+				spec.Components[i].Name = fmt.Sprintf("%s-%d", spec.Components[i].Name, i)
+			}
+		},
+		func(comp *v1beta1.Component, c fuzz.Continue) {
+			c.Fuzz(&comp.Name)
+			c.Fuzz(&comp.Order)
+			comp.Type = componentTypes[c.Intn(len(componentTypes))]
+			switch comp.Type {
+			case "table":
+				c.Fuzz(&comp.Table)
+			case "chart":
+				c.Fuzz(&comp.Chart)
+			case "button":
+				c.Fuzz(&comp.Button)
+			default:
+				if c.RandBool() {
+					raw, _ := json.Marshal(fuzzConfig(c, 0))
+					comp.Config = &runtime.RawExtension{Raw: raw}
+				}
+			}
+		},
+	)
+}
+
+func TestFrontendPageSpokeRoundTrip(t *testing.T) {
+	f := spokeFuzzer()
+	for i := 0; i < fuzzIterations; i++ {
+		original := &FrontendPage{}
+		f.Fuzz(original)
+
+		hub := &v1beta1.FrontendPage{}
+		if err := original.DeepCopy().ConvertTo(hub); err != nil {
+			t.Fatalf("ConvertTo failed: %v", err)
+		}
+		got := &FrontendPage{}
+		if err := got.ConvertFrom(hub); err != nil {
+			t.Fatalf("ConvertFrom failed: %v", err)
+		}
+		if !apiequality.Semantic.DeepEqual(original, got) {
+			t.Fatalf("v1alpha1 -> v1beta1 -> v1alpha1 round trip is lossy:\nwant %+v\ngot  %+v", original, got)
+		}
+	}
+}
+
+func TestFrontendPageHubRoundTrip(t *testing.T) {
+	f := hubFuzzer()
+	for i := 0; i < fuzzIterations; i++ {
+		original := &v1beta1.FrontendPage{}
+		f.Fuzz(original)
+
+		spoke := &FrontendPage{}
+		if err := spoke.ConvertFrom(original.DeepCopy()); err != nil {
+			t.Fatalf("ConvertFrom failed: %v", err)
+		}
+		got := &v1beta1.FrontendPage{}
+		if err := spoke.ConvertTo(got); err != nil {
+			t.Fatalf("ConvertTo failed: %v", err)
+		}
+		if !apiequality.Semantic.DeepEqual(original, got) {
+			t.Fatalf("v1beta1 -> v1alpha1 -> v1beta1 round trip is lossy:\nwant %+v\ngot  %+v", original, got)
+		}
+	}
+}
+
+func TestFrontendPageConvertFromHub(t *testing.T) {
+	pageSize := int32(25)
+	hub := &v1beta1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default"},
+		Spec: v1beta1.FrontendPageSpec{
+			Title:    "Dashboard",
+			Template: "dashboard",
+			Theme:    v1beta1.Theme{Name: "dark", PrimaryColor: "#0969da"},
+			Layout:   v1beta1.Layout{Type: v1beta1.LayoutGrid, Columns: 3},
+			Components: []v1beta1.Component{
+				{Name: "metrics", Type: "table", Order: 2, Table: &v1beta1.TableConfig{Columns: []string{"Name"}, PageSize: &pageSize}},
+				{Name: "custom", Type: "carousel", Config: &runtime.RawExtension{Raw: []byte(`{"slides":3}`)}},
+			},
+		},
+	}
+
+	spoke := &FrontendPage{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom failed: %v", err)
+	}
+	if spoke.Spec.Theme != "dark" {
+		t.Errorf("expected theme dark, got %q", spoke.Spec.Theme)
+	}
+	wantTable := map[string]interface{}{"columns": []interface{}{"Name"}, "pageSize": int64(25)}
+	if !apiequality.Semantic.DeepEqual(spoke.Spec.Components[0].Config, wantTable) {
+		t.Errorf("expected table config %v, got %v", wantTable, spoke.Spec.Components[0].Config)
+	}
+	if got := spoke.Spec.Components[1].Config["slides"]; got != int64(3) {
+		t.Errorf("expected custom config to be kept, got %v", spoke.Spec.Components[1].Config)
+	}
+	if spoke.Annotations[v1beta1FieldsAnnotation] == "" {
+		t.Errorf("expected v1beta1-only fields to be kept in the %s annotation", v1beta1FieldsAnnotation)
+	}
+
+	// A v1alpha1 client renaming the theme drops the overrides of the old theme
+	spoke.Spec.Theme = "light"
+	got := &v1beta1.FrontendPage{}
+	if err := spoke.ConvertTo(got); err != nil {
+		t.Fatalf("ConvertTo failed: %v", err)
+	}
+	if got.Spec.Theme != (v1beta1.Theme{Name: "light"}) {
+		t.Errorf("expected plain light theme, got %+v", got.Spec.Theme)
+	}
+	if got.Spec.Layout != hub.Spec.Layout || got.Spec.Components[0].Order != 2 {
+		t.Errorf("expected layout and order hints to be restored, got %+v", got.Spec)
+	}
+	if _, ok := got.Annotations[v1beta1FieldsAnnotation]; ok {
+		t.Errorf("expected the %s annotation to be removed from v1beta1 objects", v1beta1FieldsAnnotation)
+	}
+}
+
+func TestFrontendPageConvertToHubKeepsInexactConfigs(t *testing.T) {
+	spoke := &FrontendPage{
+		Spec: FrontendPageSpec{
+			Components: []Component{
+				{Name: "typo", Type: "table", Config: map[string]interface{}{"colums": []interface{}{"Name"}}},
+				{Name: "mistyped", Type: "table", Config: map[string]interface{}{"columns": "Name"}},
+				{Name: "ok", Type: "button", Config: map[string]interface{}{"actions": []interface{}{"refresh"}}},
+			},
+		},
+	}
+	hub := &v1beta1.FrontendPage{}
+	if err := spoke.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo failed: %v", err)
+	}
+	for _, i := range []int{0, 1} {
+		if c := hub.Spec.Components[i]; c.Table != nil || c.Config == nil {
+			t.Errorf("expected %s config to be kept verbatim, got %+v", c.Name, c)
+		}
+	}
+	if c := hub.Spec.Components[2]; c.Button == nil || c.Button.Actions[0] != "refresh" || c.Config != nil {
+		t.Errorf("expected a typed button config, got %+v", c)
+	}
+}
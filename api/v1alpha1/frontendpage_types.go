@@ -2,7 +2,6 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // FrontendPageSpec defines the desired state of FrontendPage
@@ -11,6 +10,7 @@ type FrontendPageSpec struct {
 	Title string `json:"title"`
 
 	// Template specifies the frontend template to use
+	// +kubebuilder:validation:MinLength=1
 	Template string `json:"template"`
 
 	// Components defines the UI components to render on the page
@@ -21,25 +21,69 @@ type FrontendPageSpec struct {
 }
 
 // Component defines a UI component on the page
+//
+// +kubebuilder:object:generate=false
 type Component struct {
 	// Name is the unique identifier for the component
+	// +kubebuilder:validation:MinLength=1
 	Name string `json:"name"`
 
 	// Type specifies the component type
+	// +kubebuilder:validation:MinLength=1
 	Type string `json:"type"`
 
 	// Config contains component-specific configuration
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
 	Config map[string]interface{} `json:"config,omitempty"`
 }
 
+// Condition types reported in FrontendPageStatus.Conditions
+const (
+	// ConditionReady indicates the page is rendered and its page server is available
+	ConditionReady = "Ready"
+
+	// ConditionRendered indicates the spec was validated and rendered to HTML
+	ConditionRendered = "Rendered"
+
+	// ConditionProgressing indicates a rollout of the page server is in progress
+	ConditionProgressing = "Progressing"
+
+	// ConditionDegraded indicates the page cannot reach or stay in the desired state
+	ConditionDegraded = "Degraded"
+)
+
+// Phases summarizing FrontendPageStatus.Conditions
+const (
+	PhasePending  = "Pending"
+	PhaseReady    = "Ready"
+	PhaseInvalid  = "Invalid"
+	PhaseFailed   = "Failed"
+	PhaseDegraded = "Degraded"
+)
+
 // FrontendPageStatus defines the observed state of FrontendPage
 type FrontendPageStatus struct {
-	// Phase represents the current phase of the frontend page
+	// Phase is a summary of Conditions kept for backwards compatibility
+	// +optional
 	Phase string `json:"phase"`
 
-	// Message provides additional context about the status
+	// Message is the message of the Ready condition kept for backwards compatibility
 	Message string `json:"message,omitempty"`
 
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the page's state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
 	// URL is the generated URL for accessing the page
 	URL string `json:"url,omitempty"`
 
@@ -50,6 +94,18 @@ type FrontendPageStatus struct {
 	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
 }
 
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=fp
+// +kubebuilder:printcolumn:name="Title",type=string,JSONPath=`.spec.title`
+// +kubebuilder:printcolumn:name="Template",type=string,JSONPath=`.spec.template`
+// +kubebuilder:printcolumn:name="Theme",type=string,JSONPath=`.spec.theme`,priority=1
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
 // FrontendPage is the Schema for the frontendpages API
 type FrontendPage struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -59,120 +115,11 @@ type FrontendPage struct {
 	Status FrontendPageStatus `json:"status,omitempty"`
 }
 
+// +kubebuilder:object:root=true
+
 // FrontendPageList contains a list of FrontendPage
 type FrontendPageList struct {
 	metav1.TypeMeta `json:",inline"`
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []FrontendPage `json:"items"`
 }
-
-// DeepCopy methods (minimal implementation)
-func (in *FrontendPage) DeepCopy() *FrontendPage {
-	if in == nil {
-		return nil
-	}
-	out := new(FrontendPage)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *FrontendPage) DeepCopyInto(out *FrontendPage) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
-}
-
-func (in *FrontendPage) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
-func (in *FrontendPageSpec) DeepCopy() *FrontendPageSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(FrontendPageSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *FrontendPageSpec) DeepCopyInto(out *FrontendPageSpec) {
-	*out = *in
-	if in.Components != nil {
-		in, out := &in.Components, &out.Components
-		*out = make([]Component, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-func (in *Component) DeepCopy() *Component {
-	if in == nil {
-		return nil
-	}
-	out := new(Component)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *Component) DeepCopyInto(out *Component) {
-	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make(map[string]interface{}, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-}
-
-func (in *FrontendPageStatus) DeepCopy() *FrontendPageStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(FrontendPageStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *FrontendPageStatus) DeepCopyInto(out *FrontendPageStatus) {
-	*out = *in
-	if in.LastUpdated != nil {
-		in, out := &in.LastUpdated, &out.LastUpdated
-		*out = (*in).DeepCopy()
-	}
-}
-
-func (in *FrontendPageList) DeepCopy() *FrontendPageList {
-	if in == nil {
-		return nil
-	}
-	out := new(FrontendPageList)
-	in.DeepCopyInto(out)
-	return out
-}
-
-func (in *FrontendPageList) DeepCopyInto(out *FrontendPageList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]FrontendPage, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-func (in *FrontendPageList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
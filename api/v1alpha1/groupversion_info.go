@@ -1,3 +1,6 @@
+// Package v1alpha1 contains API Schema definitions for the frontend v1alpha1 API group
+// +kubebuilder:object:generate=true
+// +groupName=frontend.thegostev.com
 package v1alpha1
 
 import (
@@ -0,0 +1,200 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// FrontendPageSpec defines the desired state of FrontendPage
+type FrontendPageSpec struct {
+	// Title is the display title of the frontend page
+	Title string `json:"title"`
+
+	// Template specifies the frontend template to use
+	// +kubebuilder:validation:MinLength=1
+	Template string `json:"template"`
+
+	// Theme specifies the visual theme
+	// +optional
+	Theme Theme `json:"theme,omitempty"`
+
+	// Layout controls how components are arranged on the page
+	// +optional
+	Layout Layout `json:"layout,omitempty"`
+
+	// Components defines the UI components to render on the page
+	Components []Component `json:"components"`
+}
+
+// Theme selects a theme stylesheet and overrides parts of it. Overrides that
+// are not plain colors or font names are ignored when rendering.
+type Theme struct {
+	// Name is the registered theme to use
+	Name string `json:"name,omitempty"`
+
+	// PrimaryColor overrides the theme's accent color
+	PrimaryColor string `json:"primaryColor,omitempty"`
+
+	// BackgroundColor overrides the theme's page background
+	BackgroundColor string `json:"backgroundColor,omitempty"`
+
+	// FontFamily overrides the theme's font stack
+	FontFamily string `json:"fontFamily,omitempty"`
+}
+
+// Layout types
+const (
+	LayoutStack = "stack"
+	LayoutGrid  = "grid"
+)
+
+// Layout describes how components are arranged in the <main> element of the
+// page template
+type Layout struct {
+	// Type is the arrangement of components, "stack" or "grid"
+	// +kubebuilder:validation:Enum=stack;grid
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Columns is the number of columns of a grid layout; without it columns
+	// are at least 20rem wide
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Columns int32 `json:"columns,omitempty"`
+}
+
+// Component defines a UI component on the page. Built-in component types are
+// configured through their typed field; other types use Config.
+type Component struct {
+	// Name is the unique identifier for the component
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Type specifies the component type
+	// +kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+
+	// Order is a placement hint; components are placed by ascending order and
+	// components with equal order keep their list order
+	// +optional
+	Order int32 `json:"order,omitempty"`
+
+	// Table configures a "table" component
+	// +optional
+	Table *TableConfig `json:"table,omitempty"`
+
+	// Chart configures a "chart" component
+	// +optional
+	Chart *ChartConfig `json:"chart,omitempty"`
+
+	// Button configures a "button" component
+	// +optional
+	Button *ButtonConfig `json:"button,omitempty"`
+
+	// Config contains the configuration of component types without a typed config
+	// +optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config *runtime.RawExtension `json:"config,omitempty"`
+}
+
+// TableConfig configures a table component
+type TableConfig struct {
+	// Columns are the column headers
+	// +kubebuilder:validation:MinItems=1
+	Columns []string `json:"columns"`
+
+	// PageSize is the number of rows per page
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PageSize *int32 `json:"pageSize,omitempty"`
+
+	// Sortable enables sorting by column
+	Sortable *bool `json:"sortable,omitempty"`
+}
+
+// ChartConfig configures a chart component
+type ChartConfig struct {
+	// Type is the chart type: line, bar, pie or area
+	// +kubebuilder:validation:Enum=line;bar;pie;area
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Title is the chart caption
+	Title string `json:"title,omitempty"`
+
+	// DataSource is where the chart reads its data from
+	DataSource string `json:"dataSource,omitempty"`
+}
+
+// ButtonConfig configures a button group component
+type ButtonConfig struct {
+	// Actions are the actions offered, one button each
+	// +kubebuilder:validation:MinItems=1
+	Actions []string `json:"actions"`
+
+	// Style is the button style: primary, secondary or danger
+	// +kubebuilder:validation:Enum=primary;secondary;danger
+	// +optional
+	Style string `json:"style,omitempty"`
+}
+
+// FrontendPageStatus defines the observed state of FrontendPage
+type FrontendPageStatus struct {
+	// Phase is a summary of Conditions
+	// +optional
+	Phase string `json:"phase"`
+
+	// Message is the message of the Ready condition
+	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the page's state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// URL is the generated URL for accessing the page
+	URL string `json:"url,omitempty"`
+
+	// ComponentCount represents the number of components processed
+	ComponentCount int `json:"componentCount,omitempty"`
+
+	// LastUpdated tracks when the status was last updated
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:unservedversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=fp
+// +kubebuilder:printcolumn:name="Title",type=string,JSONPath=`.spec.title`
+// +kubebuilder:printcolumn:name="Template",type=string,JSONPath=`.spec.template`
+// +kubebuilder:printcolumn:name="Theme",type=string,JSONPath=`.spec.theme.name`,priority=1
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// FrontendPage is the Schema for the frontendpages API
+type FrontendPage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FrontendPageSpec   `json:"spec,omitempty"`
+	Status FrontendPageStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FrontendPageList contains a list of FrontendPage
+type FrontendPageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FrontendPage `json:"items"`
+}
@@ -0,0 +1,31 @@
+// Package v1beta1 contains API Schema definitions for the frontend v1beta1 API group
+// +kubebuilder:object:generate=true
+// +groupName=frontend.thegostev.com
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// GroupVersion is group version used to register these objects
+	GroupVersion = schema.GroupVersion{Group: "frontend.thegostev.com", Version: "v1beta1"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &runtime.SchemeBuilder{addKnownTypes}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+// Adds the list of known types to the given scheme.
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(GroupVersion,
+		&FrontendPage{},
+		&FrontendPageList{},
+	)
+	metav1.AddToGroupVersion(scheme, GroupVersion)
+	return nil
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+)
+
+var (
+	deploymentNamespace string
+	scaleReplicas       int32
+	rollbackRevision    int64
+)
+
+var deploymentCmd = &cobra.Command{
+	Use:     "deployment",
+	Aliases: []string{"deploy"},
+	Short:   "Manage Deployments",
+	Long:    `Scale, restart, pause, resume, roll back and update the images of Deployments`,
+}
+
+var scaleDeploymentCmd = &cobra.Command{
+	Use:   "scale NAME --replicas N",
+	Short: "Set the number of replicas of a Deployment",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDeploymentOperation(args[0], "scaled", func(ctx context.Context, client *k8s.Client) (*appsv1.Deployment, error) {
+			return client.ScaleDeployment(ctx, deploymentNamespace, args[0], scaleReplicas)
+		})
+	},
+}
+
+var restartDeploymentCmd = &cobra.Command{
+	Use:   "restart NAME",
+	Short: "Replace all pods of a Deployment with a rollout",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDeploymentOperation(args[0], "restarted", func(ctx context.Context, client *k8s.Client) (*appsv1.Deployment, error) {
+			return client.RestartDeployment(ctx, deploymentNamespace, args[0])
+		})
+	},
+}
+
+var pauseDeploymentCmd = &cobra.Command{
+	Use:   "pause NAME",
+	Short: "Pause the rollouts of a Deployment",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDeploymentOperation(args[0], "paused", func(ctx context.Context, client *k8s.Client) (*appsv1.Deployment, error) {
+			return client.PauseDeployment(ctx, deploymentNamespace, args[0])
+		})
+	},
+}
+
+var resumeDeploymentCmd = &cobra.Command{
+	Use:   "resume NAME",
+	Short: "Resume the rollouts of a paused Deployment",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDeploymentOperation(args[0], "resumed", func(ctx context.Context, client *k8s.Client) (*appsv1.Deployment, error) {
+			return client.ResumeDeployment(ctx, deploymentNamespace, args[0])
+		})
+	},
+}
+
+var rollbackDeploymentCmd = &cobra.Command{
+	Use:   "rollback NAME",
+	Short: "Roll a Deployment back to a previous revision",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDeploymentOperation(args[0], "rolled back", func(ctx context.Context, client *k8s.Client) (*appsv1.Deployment, error) {
+			return client.RollbackDeployment(ctx, deploymentNamespace, args[0], rollbackRevision)
+		})
+	},
+}
+
+var setImageDeploymentCmd = &cobra.Command{
+	Use:     "set-image NAME CONTAINER=IMAGE...",
+	Short:   "Update container images of a Deployment",
+	Example: "  controller deployment set-image web app=nginx:1.25 '*=busybox'",
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		images, err := parseImages(args[1:])
+		if err != nil {
+			return err
+		}
+		return runDeploymentOperation(args[0], "image updated", func(ctx context.Context, client *k8s.Client) (*appsv1.Deployment, error) {
+			return client.SetDeploymentImage(ctx, deploymentNamespace, args[0], images)
+		})
+	},
+}
+
+// parseImages parses CONTAINER=IMAGE arguments, "*" stands for all containers
+func parseImages(args []string) (map[string]string, error) {
+	images := make(map[string]string, len(args))
+	for _, arg := range args {
+		container, image, ok := strings.Cut(arg, "=")
+		if !ok || container == "" || image == "" {
+			return nil, fmt.Errorf("invalid image %q, expected CONTAINER=IMAGE", arg)
+		}
+		images[container] = image
+	}
+	return images, nil
+}
+
+// runDeploymentOperation runs an operation on a deployment and reports the result
+func runDeploymentOperation(name, result string, operation func(ctx context.Context, client *k8s.Client) (*appsv1.Deployment, error)) error {
+	logger := log.With().Str("component", "deployment-command").Logger()
+
+	// Create client configuration
+	clientConfig := &types.ClientConfig{
+		KubeconfigPath: kubeconfig,
+		Timeout:        timeout,
+	}
+
+	// Initialize Kubernetes client
+	client, err := k8s.NewClient(clientConfig)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create kubernetes client")
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if _, err := operation(ctx, client); err != nil {
+		logger.Error().Err(err).Str("name", name).Msg("deployment operation failed")
+		return err
+	}
+
+	fmt.Printf("deployment.apps/%s %s\n", name, result)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(deploymentCmd)
+	deploymentCmd.AddCommand(scaleDeploymentCmd, restartDeploymentCmd, pauseDeploymentCmd,
+		resumeDeploymentCmd, rollbackDeploymentCmd, setImageDeploymentCmd)
+
+	deploymentCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: ~/.kube/config)")
+	deploymentCmd.PersistentFlags().StringVarP(&deploymentNamespace, "namespace", "n", "default", "Namespace of the deployment")
+	deploymentCmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "Timeout for operations")
+
+	scaleDeploymentCmd.Flags().Int32Var(&scaleReplicas, "replicas", 0, "Number of replicas")
+	_ = scaleDeploymentCmd.MarkFlagRequired("replicas")
+	rollbackDeploymentCmd.Flags().Int64Var(&rollbackRevision, "to-revision", 0, "Revision to roll back to (default: the previous revision)")
+}
@@ -2,24 +2,43 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
 	"github.com/thegostev/go-kubernetes-controllers/internal/types"
 	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/printers"
 )
 
+// frontendPageResource prefixes the names of frontend pages in command output
+var frontendPageResource = "frontendpage." + v1alpha1.GroupVersion.Group
+
 var (
 	frontendPageNamespace string
+	applyFilename         string
+	applyFieldManager     string
+	applyForceConflicts   bool
+	deleteWait            bool
+	createTitle           string
+	createTemplate        string
+	createTheme           string
+	createComponents      []string
+	createConfigs         []string
 )
 
 var frontendPageCmd = &cobra.Command{
 	Use:   "frontendpage",
 	Short: "Manage FrontendPage resources",
-	Long:  `List and manage FrontendPage custom resources`,
+	Long:  `List, inspect, apply, create and delete FrontendPage custom resources`,
 }
 
 var listFrontendPageCmd = &cobra.Command{
@@ -33,23 +52,24 @@ var listFrontendPageCmd = &cobra.Command{
 func listFrontendPages() error {
 	logger := log.With().Str("component", "frontendpage-list").Logger()
 
-	// Create client configuration (following existing pattern)
-	clientConfig := &types.ClientConfig{
-		KubeconfigPath: kubeconfig,
-		Timeout:        timeout,
+	// Reject an invalid output format before contacting the cluster
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
 	}
 
-	// Initialize Kubernetes client
-	client, err := k8s.NewClient(clientConfig)
+	client, err := newFrontendPageClient()
 	if err != nil {
-		logger.Error().Err(err).Msg("failed to create kubernetes client")
-		return fmt.Errorf("failed to create kubernetes client: %w", err)
+		return err
 	}
 
 	// Create list options (following existing pattern)
 	listOptions := &types.ListOptions{
-		Namespace: frontendPageNamespace,
-		Timeout:   timeout,
+		Namespace:     frontendPageNamespace,
+		Timeout:       timeout,
+		LabelSelector: labelSelector,
+		FieldSelector: fieldSelector,
+		ChunkSize:     chunkSize,
 	}
 
 	// Create context
@@ -63,7 +83,7 @@ func listFrontendPages() error {
 	}
 
 	// Display results (following existing pattern)
-	if err := displayFrontendPages(frontendPages, frontendPageNamespace); err != nil {
+	if err := printList(printer, frontendPages, len(frontendPages.Items), listOptions.Namespace); err != nil {
 		logger.Error().Err(err).Msg("failed to display frontend pages")
 		return fmt.Errorf("failed to display frontend pages: %w", err)
 	}
@@ -71,30 +91,246 @@ func listFrontendPages() error {
 	return nil
 }
 
-func displayFrontendPages(frontendPages *v1alpha1.FrontendPageList, namespace string) error {
-	// Simple display (following existing pattern)
-	fmt.Printf("FrontendPages in namespace '%s':\n", frontendPageNamespace)
-	fmt.Printf("Found %d FrontendPage(s)\n\n", len(frontendPages.Items))
-
-	for _, page := range frontendPages.Items {
-		fmt.Printf("Name: %s\n", page.Name)
-		fmt.Printf("  Namespace: %s\n", page.Namespace)
-		fmt.Printf("  Title: %s\n", page.Spec.Title)
-		fmt.Printf("  Template: %s\n", page.Spec.Template)
-		fmt.Printf("  Components: %d\n", len(page.Spec.Components))
-		fmt.Printf("  Phase: %s\n", page.Status.Phase)
-		if page.Status.URL != "" {
-			fmt.Printf("  URL: %s\n", page.Status.URL)
+var getFrontendPageCmd = &cobra.Command{
+	Use:   "get NAME",
+	Short: "Print a FrontendPage",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		printer, err := printers.NewPrinter(output)
+		if err != nil {
+			return err
+		}
+		return runFrontendPageOperation(func(ctx context.Context, client *k8s.Client) error {
+			page, err := client.GetFrontendPage(ctx, frontendPageNamespace, args[0])
+			if err != nil {
+				return err
+			}
+			return printer.Print(os.Stdout, page)
+		})
+	},
+}
+
+var describeFrontendPageCmd = &cobra.Command{
+	Use:   "describe NAME",
+	Short: "Show the spec, status, components, children and Events of a FrontendPage",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFrontendPageOperation(func(ctx context.Context, client *k8s.Client) error {
+			description, err := client.DescribeFrontendPage(ctx, frontendPageNamespace, args[0])
+			if err != nil {
+				return err
+			}
+			return printers.DescribeFrontendPage(os.Stdout, description, time.Now())
+		})
+	},
+}
+
+var applyFrontendPageCmd = &cobra.Command{
+	Use:     "apply -f FILE",
+	Short:   "Create or update FrontendPages from a manifest with server-side apply",
+	Example: "  controller frontendpage apply -f pages.yaml --field-manager ci\n  cat page.yaml | controller frontendpage apply -f -",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pages, err := readFrontendPages(applyFilename)
+		if err != nil {
+			return err
+		}
+
+		// Pages without namespace go to --namespace, which must not contradict the manifest
+		for _, page := range pages {
+			if page.Namespace == "" {
+				page.Namespace = frontendPageNamespace
+			} else if cmd.Flags().Changed("namespace") && page.Namespace != frontendPageNamespace {
+				return fmt.Errorf("frontend page %s is in namespace %q, not %q given by --namespace", page.Name, page.Namespace, frontendPageNamespace)
+			}
+		}
+
+		return runFrontendPageOperation(func(ctx context.Context, client *k8s.Client) error {
+			for _, page := range pages {
+				if _, err := client.ApplyFrontendPage(ctx, page, applyFieldManager, applyForceConflicts); err != nil {
+					return err
+				}
+				fmt.Printf("%s/%s serverside-applied\n", frontendPageResource, page.Name)
+			}
+			return nil
+		})
+	},
+}
+
+var deleteFrontendPageCmd = &cobra.Command{
+	Use:   "delete NAME...",
+	Short: "Delete FrontendPages",
+	Long: `Delete FrontendPages. A page and its children stay until the controller has run its
+cleanup hooks and removed the finalizer; --wait blocks until then, within --timeout.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFrontendPageOperation(func(ctx context.Context, client *k8s.Client) error {
+			for _, name := range args {
+				if err := client.DeleteFrontendPage(ctx, frontendPageNamespace, name); err != nil {
+					return err
+				}
+				fmt.Printf("%s/%s deleted\n", frontendPageResource, name)
+			}
+			if !deleteWait {
+				return nil
+			}
+			for _, name := range args {
+				if err := client.WaitForFrontendPageDeletion(ctx, frontendPageNamespace, name); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	},
+}
+
+var createFrontendPageCmd = &cobra.Command{
+	Use:   "create NAME --title TITLE --template TEMPLATE",
+	Short: "Create a FrontendPage from flags",
+	Example: `  controller frontendpage create home --title Home --template dashboard \
+    --component metrics=table --config 'metrics={"columns":["Name","Status"]}' \
+    --component trend=chart --config 'trend={"type":"bar"}' \
+    --component actions=button --config 'actions={"actions":["refresh"]}'`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		components, err := parseComponents(createComponents, createConfigs)
+		if err != nil {
+			return err
+		}
+		page := &v1alpha1.FrontendPage{
+			ObjectMeta: metav1.ObjectMeta{Name: args[0], Namespace: frontendPageNamespace},
+			Spec: v1alpha1.FrontendPageSpec{
+				Title:      createTitle,
+				Template:   createTemplate,
+				Theme:      createTheme,
+				Components: components,
+			},
+		}
+
+		return runFrontendPageOperation(func(ctx context.Context, client *k8s.Client) error {
+			if err := client.CreateFrontendPage(ctx, page); err != nil {
+				return err
+			}
+			fmt.Printf("%s/%s created\n", frontendPageResource, page.Name)
+			return nil
+		})
+	},
+}
+
+// parseComponents parses NAME=TYPE arguments in order and sets the config of
+// each component from the NAME=JSON arguments of --config
+func parseComponents(args, configs []string) ([]v1alpha1.Component, error) {
+	components := make([]v1alpha1.Component, 0, len(args))
+	index := make(map[string]int, len(args))
+	for _, arg := range args {
+		name, componentType, ok := strings.Cut(arg, "=")
+		if !ok || name == "" || componentType == "" {
+			return nil, fmt.Errorf("invalid component %q, expected NAME=TYPE", arg)
 		}
-		fmt.Println()
+		index[name] = len(components)
+		components = append(components, v1alpha1.Component{Name: name, Type: componentType})
 	}
 
+	for _, arg := range configs {
+		name, data, ok := strings.Cut(arg, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid config %q, expected NAME=JSON", arg)
+		}
+		i, found := index[name]
+		if !found {
+			return nil, fmt.Errorf("config for unknown component %q, add it with --component %s=TYPE", name, name)
+		}
+		var config map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &config); err != nil {
+			return nil, fmt.Errorf("invalid config of component %q, expected a JSON object: %w", name, err)
+		}
+		components[i].Config = config
+	}
+	return components, nil
+}
+
+// readFrontendPages decodes the frontend pages of a manifest file, "-" reads stdin
+func readFrontendPages(filename string) ([]*v1alpha1.FrontendPage, error) {
+	var reader io.Reader = os.Stdin
+	if filename != "-" {
+		file, err := os.Open(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open manifest: %w", err)
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	pages, err := k8s.DecodeFrontendPages(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("no frontend pages found in %s", filename)
+	}
+	return pages, nil
+}
+
+// newFrontendPageClient creates the client of the frontendpage commands
+func newFrontendPageClient() (*k8s.Client, error) {
+	clientConfig := &types.ClientConfig{
+		KubeconfigPath: kubeconfig,
+		Timeout:        timeout,
+	}
+	client, err := k8s.NewClient(clientConfig)
+	if err != nil {
+		log.Error().Err(err).Str("component", "frontendpage-command").Msg("failed to create kubernetes client")
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	return client, nil
+}
+
+// runFrontendPageOperation runs an operation on frontend pages within --timeout
+func runFrontendPageOperation(operation func(ctx context.Context, client *k8s.Client) error) error {
+	client, err := newFrontendPageClient()
+	if err != nil {
+		return err
+	}
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := operation(ctx, client); err != nil {
+		log.Error().Err(err).Str("component", "frontendpage-command").Msg("frontend page operation failed")
+		return err
+	}
 	return nil
 }
 
 func init() {
 	rootCmd.AddCommand(frontendPageCmd)
-	frontendPageCmd.AddCommand(listFrontendPageCmd)
+	frontendPageCmd.AddCommand(listFrontendPageCmd, getFrontendPageCmd, describeFrontendPageCmd,
+		applyFrontendPageCmd, deleteFrontendPageCmd, createFrontendPageCmd)
+
+	frontendPageCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: ~/.kube/config)")
+	frontendPageCmd.PersistentFlags().StringVarP(&frontendPageNamespace, "namespace", "n", "default", "Namespace of the frontend pages")
+	frontendPageCmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "Timeout for operations")
+
+	listFrontendPageCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector, e.g. team=payments,tier!=cache")
+	listFrontendPageCmd.Flags().StringVar(&fieldSelector, "field-selector", "", "Field selector, e.g. metadata.name=home")
+	listFrontendPageCmd.Flags().StringVarP(&output, "output", "o", printers.TableFormat, "Output format: "+strings.Join(printers.Formats, "|"))
+	listFrontendPageCmd.Flags().Int64Var(&chunkSize, "chunk-size", 500, "Number of frontend pages fetched per request (0 fetches all at once)")
+
+	getFrontendPageCmd.Flags().StringVarP(&output, "output", "o", printers.TableFormat, "Output format: "+strings.Join(printers.Formats, "|"))
+
+	applyFrontendPageCmd.Flags().StringVarP(&applyFilename, "filename", "f", "", "Manifest with FrontendPages, - reads stdin")
+	_ = applyFrontendPageCmd.MarkFlagRequired("filename")
+	applyFrontendPageCmd.Flags().StringVar(&applyFieldManager, "field-manager", "k8s-controller-tutorial", "Name of the manager owning the applied fields")
+	applyFrontendPageCmd.Flags().BoolVar(&applyForceConflicts, "force-conflicts", false, "Take over fields owned by other managers instead of failing")
+
+	deleteFrontendPageCmd.Flags().BoolVar(&deleteWait, "wait", false, "Wait until the pages are gone")
 
-	listFrontendPageCmd.Flags().StringVar(&frontendPageNamespace, "namespace", "", "Namespace to list frontend pages from (default: default)")
+	createFrontendPageCmd.Flags().StringVar(&createTitle, "title", "", "Title of the page")
+	createFrontendPageCmd.Flags().StringVar(&createTemplate, "template", "", "Page template, e.g. default, landing or dashboard")
+	createFrontendPageCmd.Flags().StringVar(&createTheme, "theme", "", "Theme of the page, e.g. light or dark")
+	createFrontendPageCmd.Flags().StringArrayVar(&createComponents, "component", nil, "Component NAME=TYPE (table, chart or button), repeatable; components keep the given order")
+	createFrontendPageCmd.Flags().StringArrayVar(&createConfigs, "config", nil, `Config of a component as NAME=JSON, e.g. 'metrics={"columns":["Name"]}'`)
+	_ = createFrontendPageCmd.MarkFlagRequired("title")
+	_ = createFrontendPageCmd.MarkFlagRequired("template")
 }
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/render"
+)
+
+func TestParseComponentsBuildsValidSpec(t *testing.T) {
+	components, err := parseComponents(
+		[]string{"metrics=table", "trend=chart", "actions=button"},
+		[]string{
+			`metrics={"columns":["Name","Status"],"pageSize":20}`,
+			`trend={"type":"bar"}`,
+			`actions={"actions":["refresh"]}`,
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to parse components: %v", err)
+	}
+	page := &v1alpha1.FrontendPage{Spec: v1alpha1.FrontendPageSpec{Title: "Home", Template: "dashboard", Components: components}}
+	if errs := render.NewRenderer().Validate(page); len(errs) > 0 {
+		t.Errorf("expected the created spec to be valid, got %v", errs)
+	}
+	if components[0].Name != "metrics" || components[2].Type != "button" {
+		t.Errorf("expected the components in the given order, got %+v", components)
+	}
+
+	// Without config, tables and buttons miss their required fields
+	components, err = parseComponents([]string{"metrics=table"}, nil)
+	if err != nil {
+		t.Fatalf("failed to parse components: %v", err)
+	}
+	page.Spec.Components = components
+	if errs := render.NewRenderer().Validate(page); len(errs) == 0 {
+		t.Errorf("expected a table without columns to be invalid")
+	}
+}
+
+func TestParseComponentsRejectsInvalidArguments(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []string
+		configs    []string
+	}{
+		{"missing type", []string{"metrics"}, nil},
+		{"config for unknown component", []string{"metrics=table"}, []string{`trend={"type":"bar"}`}},
+		{"config not an object", []string{"metrics=table"}, []string{`metrics=["Name"]`}},
+		{"config without name", []string{"metrics=table"}, []string{`{"columns":["Name"]}`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseComponents(tt.components, tt.configs); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -3,19 +3,27 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/thegostev/go-kubernetes-controllers/internal/types"
 	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/printers"
 )
 
 var (
-	kubeconfig string
-	namespace  string
-	timeout    time.Duration
+	kubeconfig    string
+	namespace     string
+	timeout       time.Duration
+	labelSelector string
+	fieldSelector string
+	chunkSize     int64
+	output        string
 )
 
 var listCmd = &cobra.Command{
@@ -30,6 +38,12 @@ var listCmd = &cobra.Command{
 func listDeployments() error {
 	logger := log.With().Str("component", "list-command").Logger()
 
+	// Reject an invalid output format before contacting the cluster
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+
 	// Create client configuration
 	clientConfig := &types.ClientConfig{
 		KubeconfigPath: kubeconfig,
@@ -45,8 +59,11 @@ func listDeployments() error {
 
 	// Create list options
 	listOptions := &types.ListOptions{
-		Namespace: namespace,
-		Timeout:   timeout,
+		Namespace:     namespace,
+		Timeout:       timeout,
+		LabelSelector: labelSelector,
+		FieldSelector: fieldSelector,
+		ChunkSize:     chunkSize,
 	}
 
 	// Create context
@@ -66,7 +83,7 @@ func listDeployments() error {
 	}
 
 	// Display results
-	if err := displayDeployments(deployments); err != nil {
+	if err := printList(printer, deployments, len(deployments.Items), namespace); err != nil {
 		logger.Error().Err(err).Msg("failed to display deployments")
 		return fmt.Errorf("failed to display deployments: %w", err)
 	}
@@ -74,11 +91,19 @@ func listDeployments() error {
 	return nil
 }
 
-func displayDeployments(deployments interface{}) error {
-	// For now, just print a simple message
-	// In a real implementation, this would format the deployments properly
-	fmt.Println("Deployments listed successfully")
-	return nil
+// printList prints a list to stdout; tables of empty lists print a notice to
+// stderr instead, like kubectl get
+func printList(printer printers.Printer, list runtime.Object, count int, namespace string) error {
+	if count == 0 && isTableOutput(output) {
+		fmt.Fprintf(os.Stderr, "No resources found in %s namespace.\n", namespace)
+		return nil
+	}
+	return printer.Print(os.Stdout, list)
+}
+
+// isTableOutput reports whether output selects the table printer
+func isTableOutput(output string) bool {
+	return output == "" || output == printers.TableFormat || output == printers.WideFormat
 }
 
 func init() {
@@ -88,4 +113,8 @@ func init() {
 	listCmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: ~/.kube/config)")
 	listCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Namespace to list deployments from")
 	listCmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Timeout for operations")
+	listCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector, e.g. team=payments,tier!=cache")
+	listCmd.Flags().StringVar(&fieldSelector, "field-selector", "", "Field selector, e.g. metadata.name=web")
+	listCmd.Flags().StringVarP(&output, "output", "o", printers.TableFormat, "Output format: "+strings.Join(printers.Formats, "|"))
+	listCmd.Flags().Int64Var(&chunkSize, "chunk-size", 500, "Number of deployments fetched per request (0 fetches all at once)")
 }
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+)
+
+var (
+	rolloutNamespace string
+	rolloutTimeout   time.Duration
+)
+
+var rolloutCmd = &cobra.Command{
+	Use:   "rollout",
+	Short: "Manage the rollouts of Deployments",
+}
+
+var rolloutStatusCmd = &cobra.Command{
+	Use:   "status NAME",
+	Short: "Wait until the rollout of a Deployment completes",
+	Long: `Watch the rollout of a Deployment until all replicas are updated and available.
+Exits with a non-zero status if the rollout exceeds its progress deadline, the
+Deployment is deleted or --timeout expires.`,
+	Example: "  controller rollout status web -n shop --timeout 10m",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRolloutStatus(args[0])
+	},
+}
+
+func runRolloutStatus(name string) error {
+	logger := log.With().Str("component", "rollout-command").Logger()
+
+	// Create client configuration
+	clientConfig := &types.ClientConfig{
+		KubeconfigPath: kubeconfig,
+		Timeout:        timeout,
+	}
+
+	// Initialize Kubernetes client
+	client, err := k8s.NewClient(clientConfig)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create kubernetes client")
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	// Stop waiting on shutdown signals
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	err = client.WaitForRollout(ctx, rolloutNamespace, name, &types.RolloutOptions{
+		Timeout:    rolloutTimeout,
+		OnProgress: func(message string) { fmt.Println(message) },
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("deployment %q successfully rolled out\n", name)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(rolloutCmd)
+	rolloutCmd.AddCommand(rolloutStatusCmd)
+
+	rolloutCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: ~/.kube/config)")
+	rolloutCmd.PersistentFlags().StringVarP(&rolloutNamespace, "namespace", "n", "default", "Namespace of the deployment")
+	rolloutStatusCmd.Flags().DurationVar(&rolloutTimeout, "timeout", 5*time.Minute, "How long to wait for the rollout, 0 waits forever")
+}
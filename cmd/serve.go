@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/pageserver"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/render"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+var servePort int
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve rendered FrontendPages over HTTP",
+	Long:  `Serve FrontendPages rendered to HTML at /pages/{namespace}/{name} without running the controllers`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+		mgr, err := ctrl.NewManager(k8s.NewConfigOrDie(), manager.Options{
+			Scheme:  k8s.NewScheme(),
+			Metrics: metricsserver.Options{BindAddress: "0"},
+		})
+		if err != nil {
+			return err
+		}
+		addr := fmt.Sprintf(":%d", servePort)
+		if err := mgr.Add(pageserver.NewServer(addr, mgr.GetCache(), render.NewRenderer())); err != nil {
+			return err
+		}
+		return mgr.Start(ctrl.SetupSignalHandler())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().IntVar(&servePort, "port", 8080, "The port the page server binds to")
+}
@@ -1,19 +1,46 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/thegostev/go-kubernetes-controllers/pkg/controller"
 	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/pageserver"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/render"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/webhook"
+	"k8s.io/apimachinery/pkg/labels"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 var (
 	disableLeaderElection bool
 	metricsPort           int
+	enablePageServer      bool
+	pageServerPort        int
+	pageServerImage       string
+	enableWebhooks        bool
+	webhookPort           int
+	webhookCertDir        string
+
+	policyRequiredLabels        []string
+	policyRequireResources      bool
+	policyDisallowLatestTag     bool
+	policyProductionSelector    string
+	policyMinProductionReplicas int32
+	policyExemptNamespaces      []string
+	policyNamespaces            []string
+	policySelector              string
+	policyAutoRemediate         bool
 )
 
+// Leader election uses Leases and records Events
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
 var serverCmd = &cobra.Command{
 	Use:   "server",
 	Short: "Start the controller manager",
@@ -24,22 +51,91 @@ var serverCmd = &cobra.Command{
 			LeaderElection:             !disableLeaderElection,
 			LeaderElectionID:           "go-k8s-ctrl-leader-election",
 			LeaderElectionResourceLock: "leases",
+			WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
+				Port:    webhookPort,
+				CertDir: webhookCertDir,
+			}),
 		})
 		if err != nil {
 			return err
 		}
-		if err := controller.SetupDeploymentController(mgr); err != nil {
+		renderer := render.NewRenderer()
+		var cleanupHooks []controller.CleanupHook
+		if enablePageServer {
+			pageServer := pageserver.NewServer(fmt.Sprintf(":%d", pageServerPort), mgr.GetCache(), renderer)
+			if err := mgr.Add(pageServer); err != nil {
+				return err
+			}
+			cleanupHooks = append(cleanupHooks, pageServer)
+		}
+		policy, err := deploymentPolicyFromFlags()
+		if err != nil {
 			return err
 		}
-		if err := controller.SetupFrontendPageController(mgr); err != nil {
+		if err := controller.SetupDeploymentController(mgr, controller.DeploymentOptions{Policy: policy}); err != nil {
 			return err
 		}
+		if err := controller.SetupFrontendPageController(mgr, controller.FrontendPageOptions{
+			Renderer:        renderer,
+			PageServerImage: pageServerImage,
+			CleanupHooks:    cleanupHooks,
+		}); err != nil {
+			return err
+		}
+		if enableWebhooks {
+			if err := webhook.SetupFrontendPageWebhook(mgr, renderer); err != nil {
+				return err
+			}
+			if err := webhook.SetupFrontendPageConversionWebhook(mgr); err != nil {
+				return err
+			}
+		}
 		return mgr.Start(ctrl.SetupSignalHandler())
 	},
 }
 
+// deploymentPolicyFromFlags builds the policy enforced by the Deployment controller
+func deploymentPolicyFromFlags() (controller.DeploymentPolicy, error) {
+	selector, err := labels.Parse(policyProductionSelector)
+	if err != nil {
+		return controller.DeploymentPolicy{}, fmt.Errorf("invalid --policy-production-selector: %w", err)
+	}
+	scope, err := labels.Parse(policySelector)
+	if err != nil {
+		return controller.DeploymentPolicy{}, fmt.Errorf("invalid --policy-selector: %w", err)
+	}
+	return controller.DeploymentPolicy{
+		RequiredLabels:        policyRequiredLabels,
+		RequireResources:      policyRequireResources,
+		DisallowLatestTag:     policyDisallowLatestTag,
+		ProductionSelector:    selector,
+		MinProductionReplicas: policyMinProductionReplicas,
+		ExemptNamespaces:      policyExemptNamespaces,
+		Namespaces:            policyNamespaces,
+		Selector:              scope,
+		AutoRemediate:         policyAutoRemediate,
+	}, nil
+}
+
 func init() {
 	rootCmd.AddCommand(serverCmd)
 	serverCmd.Flags().BoolVar(&disableLeaderElection, "disable-leader-election", false, "Disable leader election for controller manager")
 	serverCmd.Flags().IntVar(&metricsPort, "metrics-port", 8081, "The port the metric endpoint binds to")
+	serverCmd.Flags().BoolVar(&enablePageServer, "enable-page-server", true, "Serve rendered FrontendPages from the manager")
+	serverCmd.Flags().IntVar(&pageServerPort, "port", 8080, "The port the page server binds to")
+	serverCmd.Flags().BoolVar(&enableWebhooks, "enable-webhooks", false, "Serve the FrontendPage admission and conversion webhooks")
+	serverCmd.Flags().IntVar(&webhookPort, "webhook-port", ctrlwebhook.DefaultPort, "The port the webhook server binds to")
+	serverCmd.Flags().StringVar(&webhookCertDir, "webhook-cert-dir", "", "Directory containing tls.crt and tls.key for the webhook server (default: <temp-dir>/k8s-webhook-server/serving-certs)")
+	serverCmd.Flags().StringVar(&pageServerImage, "page-server-image", controller.DefaultPageServerImage, "Image run by the Deployment created for each FrontendPage")
+
+	defaults := controller.DefaultDeploymentPolicy()
+	serverCmd.Flags().StringSliceVar(&policyRequiredLabels, "policy-required-labels", defaults.RequiredLabels, "Labels every Deployment must carry")
+	serverCmd.Flags().BoolVar(&policyRequireResources, "policy-require-resources", defaults.RequireResources, "Require CPU and memory requests and limits on every container")
+	serverCmd.Flags().BoolVar(&policyDisallowLatestTag, "policy-disallow-latest-tag", defaults.DisallowLatestTag, "Report images tagged :latest or without a tag")
+	serverCmd.Flags().StringVar(&policyProductionSelector, "policy-production-selector", defaults.ProductionSelector.String(), "Label selector of production Deployments")
+	serverCmd.Flags().Int32Var(&policyMinProductionReplicas, "policy-min-production-replicas", defaults.MinProductionReplicas, "Minimum replicas of production Deployments")
+	serverCmd.Flags().StringSliceVar(&policyExemptNamespaces, "policy-exempt-namespaces", defaults.ExemptNamespaces, "Namespaces whose Deployments are not checked")
+	serverCmd.Flags().StringSliceVar(&policyNamespaces, "policy-namespaces", nil, "Only check Deployments in these namespaces (default: all namespaces)")
+	serverCmd.Flags().StringVar(&policySelector, "policy-selector", defaults.Selector.String(), "Label selector of the Deployments that are checked; an empty selector checks all of them")
+	serverCmd.Flags().BoolVar(&policyAutoRemediate, "policy-auto-remediate", false, "Apply safe fixes (scaling production Deployments to the minimum) instead of only reporting")
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/thegostev/go-kubernetes-controllers/internal/types"
 	"github.com/thegostev/go-kubernetes-controllers/pkg/informer"
@@ -17,22 +19,41 @@ import (
 )
 
 var (
-	watchNamespace string
-	watchResync    time.Duration
-	watchWorkers   int
-	inCluster      bool
+	watchResource   string
+	watchNamespaces []string
+	watchAllNs      bool
+	watchNsSelector string
+	watchLabelSel   string
+	watchFieldSel   string
+	watchResync     time.Duration
+	watchWorkers    int
+	watchEvents     []string
+	handlerTimeout  time.Duration
+	watchHealthPort int
+
+	watchMaxConnections  int
+	watchMaxCacheSize    int
+	watchCacheSizePolicy string
+	inCluster            bool
 )
 
 var watchCmd = &cobra.Command{
 	Use:   "watch",
-	Short: "Watch Kubernetes deployments",
-	Long:  `Watch deployment events using Kubernetes informer`,
+	Short: "Watch Kubernetes resources",
+	Long: `Watch events of any built-in or custom resource using a Kubernetes informer.
+The resource is given as accepted by kubectl, e.g. deployments, statefulsets,
+daemonsets, configmaps or frontendpages.
+
+Events are watched in the namespaces given with --namespace, in all namespaces
+with --all-namespaces, or in the namespaces whose labels match
+--namespace-selector. Namespaces that start or stop matching the selector are
+picked up while running.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return watchDeployments()
+		return watchResources()
 	},
 }
 
-func watchDeployments() error {
+func watchResources() error {
 	logger := log.With().Str("component", "watch-command").Logger()
 
 	// Create client configuration
@@ -48,20 +69,56 @@ func watchDeployments() error {
 		return fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
+	// Resolve the resource to watch
+	gvr, err := client.ResolveResource(watchResource)
+	if err != nil {
+		logger.Error().Err(err).Str("resource", watchResource).Msg("failed to resolve resource")
+		return fmt.Errorf("failed to resolve resource %q: %w", watchResource, err)
+	}
+
+	// Choose the namespaces to watch
+	if watchAllNs && watchNsSelector != "" {
+		return fmt.Errorf("--all-namespaces cannot be combined with --namespace-selector")
+	}
+	namespaces := watchNamespaces
+	switch {
+	case watchAllNs:
+		namespaces = []string{metav1.NamespaceAll}
+	case watchNsSelector != "":
+		namespaces = nil
+	}
+
 	// Create informer configuration
 	informerConfig := &types.InformerConfig{
-		Namespace:    watchNamespace,
-		ResyncPeriod: watchResync,
-		Workers:      watchWorkers,
+		Namespaces:        namespaces,
+		NamespaceSelector: watchNsSelector,
+		LabelSelector:     watchLabelSel,
+		FieldSelector:     watchFieldSel,
+		ResyncPeriod:      watchResync,
+		Workers:           watchWorkers,
+		MaxConnections:    watchMaxConnections,
+		MaxCacheSize:      watchMaxCacheSize,
+		CacheSizePolicy:   watchCacheSizePolicy,
 	}
 
 	// Create informer
-	inf, err := informer.NewInformer(client.GetClientset(), informerConfig)
+	inf, err := informer.NewMultiNamespaceInformerForConfig(client.GetRESTConfig(), gvr, informerConfig)
 	if err != nil {
 		logger.Error().Err(err).Msg("failed to create informer")
 		return fmt.Errorf("failed to create informer: %w", err)
 	}
 
+	// Log events through a handler chain; custom automation registers its own
+	// handlers the same way when using the informer as a library
+	var handler informer.EventHandler = informer.LogHandler(logger)
+	if len(watchEvents) > 0 {
+		handler = informer.Filtered(handler, informer.EventTypeFilter(watchEvents...))
+	}
+	inf.AddEventHandler(informer.Chain(handler,
+		informer.RecoveryMiddleware(logger),
+		informer.TimeoutMiddleware(handlerTimeout),
+	))
+
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -76,16 +133,39 @@ func watchDeployments() error {
 		cancel()
 	}()
 
+	// Serve health endpoints while the cache syncs, so /readyz reflects the sync
+	if watchHealthPort > 0 {
+		healthServer := newHealthServer(inf, watchHealthPort)
+		go func() {
+			if err := healthServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error().Err(err).Msg("health server failed")
+			}
+		}()
+		defer func() {
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer shutdownCancel()
+			if err := healthServer.Shutdown(shutdownCtx); err != nil {
+				logger.Error().Err(err).Msg("failed to stop health server")
+			}
+		}()
+		logger.Info().Int("port", watchHealthPort).Msg("serving /healthz and /readyz")
+	}
+
 	// Start informer
 	if err := inf.Start(ctx); err != nil {
 		logger.Error().Err(err).Msg("failed to start informer")
 		return fmt.Errorf("failed to start informer: %w", err)
 	}
 
-	logger.Info().Msg("watching deployment events (press Ctrl+C to stop)")
+	logger.Info().Str("resource", inf.Resource()).Strs("namespaces", inf.Namespaces()).Msg("watching events (press Ctrl+C to stop)")
 
-	// Wait for context cancellation
-	<-ctx.Done()
+	// Wait for a shutdown signal or for the informer to fail, for example
+	// when the refuse cache size policy stops it
+	select {
+	case <-ctx.Done():
+	case <-inf.Done():
+	}
+	failure := inf.Err()
 
 	// Stop informer
 	stopCtx, stopCancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -96,16 +176,43 @@ func watchDeployments() error {
 		return fmt.Errorf("failed to stop informer: %w", err)
 	}
 
+	if failure != nil {
+		logger.Error().Err(failure).Msg("informer failed")
+		return fmt.Errorf("informer failed: %w", failure)
+	}
 	logger.Info().Msg("watch stopped successfully")
 	return nil
 }
 
+// newHealthServer serves the informer health on /healthz and /readyz
+func newHealthServer(inf *informer.MultiNamespaceInformer, port int) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", inf.HealthzHandler())
+	mux.Handle("/readyz", inf.ReadyzHandler())
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(watchCmd)
 
 	// Add flags
-	watchCmd.Flags().StringVar(&watchNamespace, "namespace", "default", "Namespace to watch")
+	watchCmd.Flags().StringVar(&watchResource, "resource", "deployments", "Resource to watch, e.g. deployments, statefulsets, configmaps, frontendpages")
+	watchCmd.Flags().StringSliceVarP(&watchNamespaces, "namespace", "n", []string{"default"}, "Namespaces to watch, comma separated or repeated")
+	watchCmd.Flags().BoolVarP(&watchAllNs, "all-namespaces", "A", false, "Watch all namespaces")
+	watchCmd.Flags().StringVar(&watchNsSelector, "namespace-selector", "", "Watch the namespaces whose labels match this selector, e.g. team=payments; followed while running")
+	watchCmd.Flags().StringVarP(&watchLabelSel, "selector", "l", "", "Label selector, e.g. team=payments; objects that stop matching are reported as deleted")
+	watchCmd.Flags().StringVar(&watchFieldSel, "field-selector", "", "Field selector, e.g. metadata.name=web")
 	watchCmd.Flags().DurationVar(&watchResync, "resync", 10*time.Minute, "Resync period")
-	watchCmd.Flags().IntVar(&watchWorkers, "workers", 2, "Number of event workers")
+	watchCmd.Flags().IntVar(&watchWorkers, "workers", 2, "Number of event workers per watched namespace")
+	watchCmd.Flags().StringSliceVar(&watchEvents, "event-types", nil, "Only report these event types (add, update, delete); all if empty")
+	watchCmd.Flags().IntVar(&watchMaxConnections, "max-connections", 10, "Maximum requests to the API server in flight, not counting watches; also sets the request rate (QPS) and twice that as burst")
+	watchCmd.Flags().IntVar(&watchMaxCacheSize, "max-cache-size", 1000, "Maximum number of cached objects across all watched namespaces")
+	watchCmd.Flags().StringVar(&watchCacheSizePolicy, "cache-size-policy", types.CacheSizePolicyWarn, "What to do when the cache exceeds --max-cache-size: warn or refuse")
+	watchCmd.Flags().IntVar(&watchHealthPort, "health-port", 8082, "Port serving /healthz and /readyz (0 disables)")
+	watchCmd.Flags().DurationVar(&handlerTimeout, "handler-timeout", 30*time.Second, "Timeout for handling a single event")
 	watchCmd.Flags().BoolVar(&inCluster, "in-cluster", false, "Use in-cluster authentication")
 }
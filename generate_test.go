@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// generatedFiles are the checked-in outputs of `make generate manifests`
+var generatedFiles = []string{
+	"api/*/zz_generated.deepcopy.go",
+	"config/crd/bases/*.yaml",
+	"config/rbac/role.yaml",
+	"config/webhook/manifests.yaml",
+}
+
+// TestGeneratedFilesUpToDate regenerates deepcopy code and manifests in a copy of the
+// repository and fails if they differ from the checked-in files
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	controllerGen := os.Getenv("CONTROLLER_GEN")
+	if controllerGen == "" {
+		controllerGen, _ = filepath.Abs(filepath.Join("bin", "controller-gen"))
+	}
+	if _, err := os.Stat(controllerGen); err != nil {
+		t.Skipf("controller-gen not found at %s, run `make controller-gen`", controllerGen)
+	}
+	if _, err := exec.LookPath("make"); err != nil {
+		t.Skip("make not found")
+	}
+
+	dir := t.TempDir()
+	if err := copyTree(".", dir); err != nil {
+		t.Fatalf("failed to copy repository: %v", err)
+	}
+	cmd := exec.Command("make", "generate", "manifests", "CONTROLLER_GEN="+controllerGen)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("make generate manifests failed: %v\n%s", err, out)
+	}
+
+	for _, pattern := range generatedFiles {
+		want, _ := filepath.Glob(filepath.Join(dir, pattern))
+		got, _ := filepath.Glob(pattern)
+		if len(want) != len(got) {
+			t.Errorf("%s: expected %d generated files, found %d checked in; run `make generate manifests`", pattern, len(want), len(got))
+			continue
+		}
+		for _, generated := range want {
+			rel, _ := filepath.Rel(dir, generated)
+			expected, err := os.ReadFile(generated)
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", generated, err)
+			}
+			actual, err := os.ReadFile(rel)
+			if err != nil {
+				t.Errorf("%s is not checked in; run `make generate manifests`", rel)
+				continue
+			}
+			if !bytes.Equal(expected, actual) {
+				t.Errorf("%s is out of date; run `make generate manifests`", rel)
+			}
+		}
+	}
+}
+
+// copyTree copies the source tree without VCS data and local binaries
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && (d.Name() == ".git" || path == "bin") {
+			return filepath.SkipDir
+		}
+		target := filepath.Join(dst, path)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o644)
+	})
+}
@@ -3,17 +3,52 @@ package types
 import (
 	"time"
 
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
 	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
 )
 
+// Cache size policies decide what happens when an informer caches more than MaxCacheSize objects
+const (
+	// CacheSizePolicyWarn logs a warning and reports the exceeded limit in the informer health
+	CacheSizePolicyWarn = "warn"
+	// CacheSizePolicyRefuse refuses to start, or stops a running informer, once the limit is
+	// exceeded; the objects are counted page by page before the initial list fills the cache
+	CacheSizePolicyRefuse = "refuse"
+)
+
 // InformerConfig represents informer configuration
 type InformerConfig struct {
-	Namespace       string        `json:"namespace"`
-	ResyncPeriod    time.Duration `json:"resyncPeriod"`
-	MaxCacheSize    int           `json:"maxCacheSize"`
-	MaxConnections  int           `json:"maxConnections"`
-	EventBufferSize int           `json:"eventBufferSize"`
-	Workers         int           `json:"workers"`
+	Namespace string `json:"namespace"`
+	// Namespaces are the namespaces watched by a MultiNamespaceInformer, where
+	// "" stands for all namespaces; NamespaceSelector instead selects them by
+	// their labels. Without either it watches Namespace.
+	Namespaces        []string `json:"namespaces,omitempty"`
+	NamespaceSelector string   `json:"namespaceSelector,omitempty"`
+	// LabelSelector and FieldSelector restrict the watched objects on the server
+	LabelSelector string        `json:"labelSelector,omitempty"`
+	FieldSelector string        `json:"fieldSelector,omitempty"`
+	ResyncPeriod  time.Duration `json:"resyncPeriod"`
+	// MaxCacheSize is the number of objects the informer may cache, across all
+	// namespaces of a multi-namespace informer; CacheSizePolicy decides what
+	// happens when it caches more
+	MaxCacheSize    int    `json:"maxCacheSize"`
+	CacheSizePolicy string `json:"cacheSizePolicy"`
+	// MaxConnections caps the requests in flight, other than watches, and the
+	// request rate to the API server. Only the informer constructors that
+	// create their own client (the *ForConfig ones) apply it; with a client
+	// passed in, that client's own limits are used instead.
+	MaxConnections int `json:"maxConnections"`
+	MaxRetries     int `json:"maxRetries"`
+	// Workers is the number of event workers, started for each namespace of
+	// a multi-namespace informer
+	Workers int `json:"workers"`
+	// IndexLabels are the label keys indexed for lookups by label, e.g. "team"
+	IndexLabels []string `json:"indexLabels,omitempty"`
+	// Indexers are custom indexes maintained next to the built-in image,
+	// owner and label indexes
+	Indexers cache.Indexers `json:"-"`
 }
 
 // Validate validates InformerConfig
@@ -22,16 +57,38 @@ func (c *InformerConfig) Validate() error {
 		return errors.NewValidationError("resyncPeriod", "must be between 1s and 30m")
 	}
 
+	if len(c.Namespaces) > 0 && c.NamespaceSelector != "" {
+		return errors.NewValidationError("namespaces", "cannot be combined with namespaceSelector")
+	}
+
+	for _, namespace := range c.Namespaces {
+		if namespace == "" && len(c.Namespaces) > 1 {
+			return errors.NewValidationError("namespaces", "all namespaces (\"\") cannot be combined with other namespaces")
+		}
+	}
+
+	if _, err := labels.Parse(c.NamespaceSelector); err != nil {
+		return errors.NewValidationError("namespaceSelector", err.Error())
+	}
+
+	if err := validateSelectors(c.LabelSelector, c.FieldSelector); err != nil {
+		return err
+	}
+
 	if c.MaxCacheSize <= 0 {
 		return errors.NewValidationError("maxCacheSize", "must be positive")
 	}
 
+	if c.CacheSizePolicy != CacheSizePolicyWarn && c.CacheSizePolicy != CacheSizePolicyRefuse {
+		return errors.NewValidationError("cacheSizePolicy", "must be warn or refuse")
+	}
+
 	if c.MaxConnections <= 0 {
 		return errors.NewValidationError("maxConnections", "must be positive")
 	}
 
-	if c.EventBufferSize <= 0 {
-		return errors.NewValidationError("eventBufferSize", "must be positive")
+	if c.MaxRetries < 0 {
+		return errors.NewValidationError("maxRetries", "cannot be negative")
 	}
 
 	if c.Workers <= 0 {
@@ -49,11 +106,14 @@ func (c *InformerConfig) SetDefaults() {
 	if c.MaxCacheSize == 0 {
 		c.MaxCacheSize = 1000
 	}
+	if c.CacheSizePolicy == "" {
+		c.CacheSizePolicy = CacheSizePolicyWarn
+	}
 	if c.MaxConnections == 0 {
 		c.MaxConnections = 10
 	}
-	if c.EventBufferSize == 0 {
-		c.EventBufferSize = 100
+	if c.MaxRetries == 0 {
+		c.MaxRetries = 5
 	}
 	if c.Workers == 0 {
 		c.Workers = 2
@@ -62,18 +122,47 @@ func (c *InformerConfig) SetDefaults() {
 
 // InformerHealth represents informer health status
 type InformerHealth struct {
-	IsHealthy bool      `json:"isHealthy"`
-	LastSync  time.Time `json:"lastSync"`
-	Error     error     `json:"error,omitempty"`
-	CacheSize int       `json:"cacheSize"`
-	Workers   int       `json:"workers"`
+	// IsHealthy is set while all workers run and the watch is not failing
+	IsHealthy bool `json:"isHealthy"`
+	// IsReady is set once the cache has synced, as long as the informer is healthy
+	IsReady bool `json:"isReady"`
+	// Namespaces are the namespaces watched by a MultiNamespaceInformer
+	Namespaces []string `json:"namespaces,omitempty"`
+	// LastSync is the last time the informer heard from the API server: the
+	// initial cache sync or the latest add, update or delete
+	LastSync time.Time `json:"lastSync"`
+	// LastResync is the last periodic resync of the cache
+	LastResync time.Time `json:"lastResync,omitempty"`
+	// Error is the last watch error and LastErrorTime when it occurred
+	Error         string    `json:"error,omitempty"`
+	LastErrorTime time.Time `json:"lastErrorTime,omitempty"`
+	CacheSize     int       `json:"cacheSize"`
+	MaxCacheSize  int       `json:"maxCacheSize"`
+	// CacheLimitExceeded is set while the cache holds more than MaxCacheSize objects
+	CacheLimitExceeded bool `json:"cacheLimitExceeded"`
+	// Workers is the configured number of workers and WorkersAlive the number running
+	Workers      int `json:"workers"`
+	WorkersAlive int `json:"workersAlive"`
+	// LastProcessed is the last time a worker processed an event
+	LastProcessed time.Time `json:"lastProcessed,omitempty"`
+	// QueueLength is the number of keys waiting to be processed
+	QueueLength int `json:"queueLength"`
+	// DroppedEvents, RetriedEvents and CoalescedEvents count events that were
+	// given up on, requeued after a failure, or merged into a pending event
+	DroppedEvents   int64 `json:"droppedEvents"`
+	RetriedEvents   int64 `json:"retriedEvents"`
+	CoalescedEvents int64 `json:"coalescedEvents"`
 }
 
-// Event represents a deployment event
+// Event represents a change to a watched object
 type Event struct {
-	Type      string      `json:"type"` // "add", "update", "delete"
+	Type      string      `json:"type"`     // "add", "update", "delete"
+	Resource  string      `json:"resource"` // e.g. "deployments.apps", "configmaps"
 	Namespace string      `json:"namespace"`
 	Name      string      `json:"name"`
 	Timestamp time.Time   `json:"timestamp"`
 	Object    interface{} `json:"object,omitempty"`
+	// FinalStateUnknown is set on delete events recovered from a tombstone:
+	// the watch missed the delete and Object is the last state seen in the cache.
+	FinalStateUnknown bool `json:"finalStateUnknown,omitempty"`
 }
@@ -3,6 +3,9 @@ package types
 import (
 	"time"
 
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+
 	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
 )
 
@@ -10,6 +13,13 @@ import (
 type ListOptions struct {
 	Namespace string        `json:"namespace"`
 	Timeout   time.Duration `json:"timeout"`
+	// LabelSelector and FieldSelector restrict the listed objects on the
+	// server, e.g. "team=payments" and "metadata.name=web"
+	LabelSelector string `json:"labelSelector,omitempty"`
+	FieldSelector string `json:"fieldSelector,omitempty"`
+	// ChunkSize is the number of objects fetched per request, 0 fetches all
+	// objects in one request
+	ChunkSize int64 `json:"chunkSize,omitempty"`
 }
 
 // Validate validates ListOptions
@@ -24,6 +34,22 @@ func (o *ListOptions) Validate() error {
 		return errors.NewValidationError("timeout", "must be between 1s and 5m")
 	}
 
+	// Validate chunk size
+	if o.ChunkSize < 0 {
+		return errors.NewValidationError("chunkSize", "cannot be negative")
+	}
+
+	return validateSelectors(o.LabelSelector, o.FieldSelector)
+}
+
+// validateSelectors validates label and field selectors with the parsers of the API server
+func validateSelectors(labelSelector, fieldSelector string) error {
+	if _, err := labels.Parse(labelSelector); err != nil {
+		return errors.NewValidationError("labelSelector", err.Error())
+	}
+	if _, err := fields.ParseSelector(fieldSelector); err != nil {
+		return errors.NewValidationError("fieldSelector", err.Error())
+	}
 	return nil
 }
 
@@ -37,6 +63,24 @@ func (o *ListOptions) SetDefaults() {
 	}
 }
 
+// RolloutOptions represents options for waiting for a rollout
+type RolloutOptions struct {
+	// Timeout bounds the wait, 0 waits until the context is done
+	Timeout time.Duration `json:"timeout"`
+	// OnProgress, if set, receives a message whenever the rollout progresses
+	OnProgress func(message string) `json:"-"`
+}
+
+// Validate validates RolloutOptions
+func (o *RolloutOptions) Validate() error {
+	// Validate timeout
+	if o.Timeout < 0 {
+		return errors.NewValidationError("timeout", "cannot be negative")
+	}
+
+	return nil
+}
+
 // ClientConfig represents Kubernetes client configuration
 type ClientConfig struct {
 	KubeconfigPath string        `json:"kubeconfigPath"`
@@ -27,6 +27,43 @@ func TestListOptionsValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid selectors",
+			options: ListOptions{
+				Namespace:     "default",
+				Timeout:       30 * time.Second,
+				LabelSelector: "team=payments,tier notin (cache)",
+				FieldSelector: "metadata.name=web,status.phase!=Running",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid label selector",
+			options: ListOptions{
+				Namespace:     "default",
+				Timeout:       30 * time.Second,
+				LabelSelector: "team in (payments",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid field selector",
+			options: ListOptions{
+				Namespace:     "default",
+				Timeout:       30 * time.Second,
+				FieldSelector: "metadata.name",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative chunk size",
+			options: ListOptions{
+				Namespace: "default",
+				Timeout:   30 * time.Second,
+				ChunkSize: -1,
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid timeout",
 			options: ListOptions{
@@ -0,0 +1,64 @@
+package components
+
+// builtinType is a component type registered by NewDefaultRegistry
+type builtinType struct {
+	name     string
+	schema   string
+	template string
+}
+
+var builtinTypes = []builtinType{
+	{
+		name: "table",
+		schema: `{
+  "type": "object",
+  "required": ["columns"],
+  "additionalProperties": false,
+  "properties": {
+    "columns": {"type": "array", "minItems": 1, "items": {"type": "string"}},
+    "pageSize": {"type": "integer", "minimum": 1},
+    "sortable": {"type": "boolean"}
+  }
+}`,
+		template: `<section class="component component-table" id="{{ .Name }}">
+<table{{ if .Config.sortable }} data-sortable="true"{{ end }}{{ with .Config.pageSize }} data-page-size="{{ . }}"{{ end }}>
+<thead><tr>{{ range .Config.columns }}<th>{{ . }}</th>{{ end }}</tr></thead>
+<tbody></tbody>
+</table>
+</section>`,
+	},
+	{
+		name: "chart",
+		schema: `{
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "type": {"type": "string", "enum": ["line", "bar", "pie", "area"]},
+    "title": {"type": "string"},
+    "dataSource": {"type": "string"}
+  }
+}`,
+		template: `<section class="component component-chart" id="{{ .Name }}">
+<figure data-chart-type="{{ or .Config.type "line" }}"{{ with .Config.dataSource }} data-source="{{ . }}"{{ end }}>
+{{- with .Config.title }}<figcaption>{{ . }}</figcaption>{{ end -}}
+</figure>
+</section>`,
+	},
+	{
+		name: "button",
+		schema: `{
+  "type": "object",
+  "required": ["actions"],
+  "additionalProperties": false,
+  "properties": {
+    "actions": {"type": "array", "minItems": 1, "items": {"type": "string"}},
+    "style": {"type": "string", "enum": ["primary", "secondary", "danger"]}
+  }
+}`,
+		template: `<section class="component component-button" id="{{ .Name }}">
+{{- range .Config.actions }}
+<button type="button" class="{{ or $.Config.style "primary" }}" data-action="{{ . }}">{{ . }}</button>
+{{- end }}
+</section>`,
+	},
+}
@@ -0,0 +1,213 @@
+package components
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	openapierrors "k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+)
+
+// Data is the data passed to component render functions
+type Data struct {
+	Name   string
+	Type   string
+	Config map[string]interface{}
+}
+
+// RenderFunc renders a component with an already validated config to HTML
+type RenderFunc func(data Data) (template.HTML, error)
+
+// Type is a registered component type
+type Type struct {
+	// Name is the value of Component.Type selecting this type
+	Name string
+
+	// Schema is the JSON schema the component config must satisfy
+	Schema *spec.Schema
+
+	// Render renders the component to HTML
+	Render RenderFunc
+
+	validator *validate.SchemaValidator
+}
+
+// Registry holds the known component types. It is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	types   map[string]*Type
+	version uint64
+}
+
+// NewRegistry creates an empty component registry
+func NewRegistry() *Registry {
+	return &Registry{types: make(map[string]*Type)}
+}
+
+// NewDefaultRegistry creates a registry with the built-in table, chart and button types
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, b := range builtinTypes {
+		render, err := TemplateRender(b.name, b.template)
+		if err != nil {
+			panic(err)
+		}
+		if err := r.Register(b.name, b.schema, render); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register registers (or replaces) a component type. schemaJSON is a JSON schema
+// document describing the component config.
+func (r *Registry) Register(name, schemaJSON string, render RenderFunc) error {
+	if name == "" {
+		return fmt.Errorf("component type name cannot be empty")
+	}
+	if render == nil {
+		return fmt.Errorf("component type %q has no render function", name)
+	}
+	schema := &spec.Schema{}
+	if err := json.Unmarshal([]byte(schemaJSON), schema); err != nil {
+		return fmt.Errorf("invalid schema for component type %q: %w", name, err)
+	}
+
+	t := &Type{
+		Name:      name,
+		Schema:    schema,
+		Render:    render,
+		validator: validate.NewSchemaValidator(schema, nil, "", strfmt.Default),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types[name] = t
+	r.version++
+	return nil
+}
+
+// Lookup returns the component type with the given name
+func (r *Registry) Lookup(name string) (*Type, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.types[name]
+	return t, ok
+}
+
+// Types returns the sorted names of all registered component types
+func (r *Registry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.types))
+	for name := range r.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Version is incremented every time a component type is registered
+func (r *Registry) Version() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.version
+}
+
+// Validate checks that every component has a registered type and a config
+// matching that type's schema
+func (r *Registry) Validate(components []v1alpha1.Component, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, component := range components {
+		idxPath := fldPath.Index(i)
+		t, ok := r.Lookup(component.Type)
+		if !ok {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("type"), component.Type, r.Types()))
+			continue
+		}
+		allErrs = append(allErrs, t.ValidateConfig(component.Config, idxPath.Child("config"))...)
+	}
+	return allErrs
+}
+
+// ValidateConfig validates a component config against the type's schema
+func (t *Type) ValidateConfig(config map[string]interface{}, fldPath *field.Path) field.ErrorList {
+	// Round-trip through JSON so Go-constructed configs are validated exactly as the API server would see them
+	var value interface{} = map[string]interface{}{}
+	if config != nil {
+		raw, err := json.Marshal(config)
+		if err != nil {
+			return field.ErrorList{field.Invalid(fldPath, config, err.Error())}
+		}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return field.ErrorList{field.Invalid(fldPath, config, err.Error())}
+		}
+	}
+	result := t.validator.Validate(value)
+	if result.IsValid() {
+		return nil
+	}
+	return toFieldErrors(fldPath, result)
+}
+
+// toFieldErrors converts kube-openapi validation results to field errors
+func toFieldErrors(fldPath *field.Path, result *validate.Result) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, err := range result.Errors {
+		verr, ok := err.(*openapierrors.Validation)
+		if !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath, "", err.Error()))
+			continue
+		}
+
+		errPath := fldPath
+		if name := strings.TrimPrefix(verr.Name, "."); name != "" {
+			for _, part := range strings.Split(name, ".") {
+				errPath = errPath.Child(part)
+			}
+		}
+
+		switch verr.Code() {
+		case openapierrors.RequiredFailCode:
+			allErrs = append(allErrs, field.Required(errPath, ""))
+		case openapierrors.UnallowedPropertyCode:
+			allErrs = append(allErrs, field.Forbidden(errPath.Child(fmt.Sprint(verr.Value)), "unknown field"))
+		case openapierrors.EnumFailCode:
+			values := make([]string, 0, len(verr.Values))
+			for _, v := range verr.Values {
+				values = append(values, fmt.Sprint(v))
+			}
+			allErrs = append(allErrs, field.NotSupported(errPath, verr.Value, values))
+		case openapierrors.InvalidTypeCode:
+			allErrs = append(allErrs, field.TypeInvalid(errPath, verr.Value, verr.Error()))
+		default:
+			allErrs = append(allErrs, field.Invalid(errPath, verr.Value, verr.Error()))
+		}
+	}
+	return allErrs
+}
+
+// TemplateRender returns a RenderFunc executing the given html/template with the component Data
+func TemplateRender(name, text string) (RenderFunc, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse component template %q: %w", name, err)
+	}
+	return func(data Data) (template.HTML, error) {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return template.HTML(buf.String()), nil
+	}, nil
+}
@@ -0,0 +1,92 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+)
+
+func TestRegistryValidate(t *testing.T) {
+	r := NewDefaultRegistry()
+
+	tests := []struct {
+		name      string
+		component v1alpha1.Component
+		wantErrs  []string
+	}{
+		{
+			name:      "valid table",
+			component: v1alpha1.Component{Name: "t", Type: "table", Config: map[string]interface{}{"columns": []interface{}{"Name"}, "pageSize": 10}},
+		},
+		{
+			name:      "valid chart without config",
+			component: v1alpha1.Component{Name: "c", Type: "chart"},
+		},
+		{
+			name:      "unknown type",
+			component: v1alpha1.Component{Name: "x", Type: "carousel"},
+			wantErrs:  []string{`spec.components[0].type: Unsupported value: "carousel"`},
+		},
+		{
+			name:      "typo in table config",
+			component: v1alpha1.Component{Name: "t", Type: "table", Config: map[string]interface{}{"colums": []interface{}{"Name"}}},
+			wantErrs:  []string{"spec.components[0].config.columns: Required value", "spec.components[0].config.colums: Forbidden: unknown field"},
+		},
+		{
+			name:      "unsupported chart type",
+			component: v1alpha1.Component{Name: "c", Type: "chart", Config: map[string]interface{}{"type": "radar"}},
+			wantErrs:  []string{`spec.components[0].config.type: Unsupported value: "radar"`},
+		},
+		{
+			name:      "wrong type for button actions",
+			component: v1alpha1.Component{Name: "b", Type: "button", Config: map[string]interface{}{"actions": "refresh"}},
+			wantErrs:  []string{"spec.components[0].config.actions: Invalid value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := r.Validate([]v1alpha1.Component{tt.component}, field.NewPath("spec", "components"))
+			if len(errs) != len(tt.wantErrs) {
+				t.Fatalf("expected %d errors, got %v", len(tt.wantErrs), errs)
+			}
+			for _, want := range tt.wantErrs {
+				found := false
+				for _, err := range errs {
+					if strings.Contains(err.Error(), want) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("expected an error containing %q, got %v", want, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistryRender(t *testing.T) {
+	r := NewDefaultRegistry()
+	table, ok := r.Lookup("table")
+	if !ok {
+		t.Fatalf("expected table type to be registered")
+	}
+	html, err := table.Render(Data{Name: "metrics", Type: "table", Config: map[string]interface{}{"columns": []interface{}{"Name", "Value"}}})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(string(html), "<th>Name</th><th>Value</th>") {
+		t.Errorf("unexpected table output: %s", html)
+	}
+
+	if err := r.Register("broken", `{not json`, nil); err == nil {
+		t.Errorf("expected an error registering a type without render function")
+	}
+	render, _ := TemplateRender("broken", "")
+	if err := r.Register("broken", `{not json`, render); err == nil {
+		t.Errorf("expected an error registering a type with an invalid schema")
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
@@ -11,21 +14,105 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
-// DeploymentReconciler logs reconcile requests for Deployments
-// (no business logic, just logs for demonstration)
+// DeploymentReconciler enforces a DeploymentPolicy on the Deployments it applies
+// to. Violations are reported as Events and in the PolicyViolationsAnnotation of
+// the Deployment, which is removed again once the policy no longer applies.
 type DeploymentReconciler struct {
 	client.Client
+	Recorder record.EventRecorder
+	Policy   DeploymentPolicy
 }
 
+// DeploymentOptions configures the Deployment controller
+type DeploymentOptions struct {
+	// Policy is the policy enforced on Deployments
+	Policy DeploymentPolicy
+}
+
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
 func (r *DeploymentReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
-	log.FromContext(ctx).Info("Reconciling Deployment", "namespace", req.Namespace, "name", req.Name)
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("Reconciling Deployment", "namespace", req.Namespace, "name", req.Name)
+
+	dep := &appsv1.Deployment{}
+	if err := r.Get(ctx, req.NamespacedName, dep); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if !dep.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+	if !r.Policy.Applies(dep) {
+		return reconcile.Result{}, r.clearViolations(ctx, dep)
+	}
+
+	original := dep.DeepCopy()
+	var fixes []string
+	if r.Policy.AutoRemediate {
+		hpas := &autoscalingv2.HorizontalPodAutoscalerList{}
+		if err := r.List(ctx, hpas, client.InNamespace(dep.Namespace)); err != nil {
+			logger.Error(err, "failed to list HorizontalPodAutoscalers")
+			return reconcile.Result{}, err
+		}
+		fixes = r.Policy.Remediate(dep, hpas.Items)
+	}
+	violations := r.Policy.Check(dep)
+	rules := violationRules(violations)
+	if len(fixes) == 0 && rules == dep.Annotations[PolicyViolationsAnnotation] {
+		return reconcile.Result{}, nil
+	}
+
+	if rules == "" {
+		delete(dep.Annotations, PolicyViolationsAnnotation)
+	} else {
+		if dep.Annotations == nil {
+			dep.Annotations = map[string]string{}
+		}
+		dep.Annotations[PolicyViolationsAnnotation] = rules
+	}
+	if err := r.Patch(ctx, dep, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		logger.Error(err, "failed to update Deployment policy state")
+		return reconcile.Result{}, err
+	}
+
+	// Events are only recorded when the set of violations changes, not on every reconcile
+	for _, fix := range fixes {
+		r.Recorder.Event(dep, corev1.EventTypeNormal, "PolicyRemediated", fix)
+	}
+	if rules != original.Annotations[PolicyViolationsAnnotation] {
+		for _, v := range violations {
+			r.Recorder.Event(dep, corev1.EventTypeWarning, v.Rule, v.Message)
+		}
+		if rules == "" {
+			r.Recorder.Event(dep, corev1.EventTypeNormal, "PolicyCompliant", "Deployment complies with the policy")
+		}
+		logger.Info("Deployment policy violations changed", "violations", rules)
+	}
 	return reconcile.Result{}, nil
 }
 
+// clearViolations removes the PolicyViolationsAnnotation of a Deployment the
+// policy no longer applies to
+func (r *DeploymentReconciler) clearViolations(ctx context.Context, dep *appsv1.Deployment) error {
+	if _, ok := dep.Annotations[PolicyViolationsAnnotation]; !ok {
+		return nil
+	}
+	original := dep.DeepCopy()
+	delete(dep.Annotations, PolicyViolationsAnnotation)
+	if err := r.Patch(ctx, dep, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		log.FromContext(ctx).Error(err, "failed to clear Deployment policy violations")
+		return err
+	}
+	return nil
+}
+
 // DeploymentEventHandler logs all Deployment events
 var DeploymentEventHandler = handler.Funcs{
 	CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
@@ -47,16 +134,31 @@ var DeploymentEventHandler = handler.Funcs{
 }
 
 // SetupDeploymentController registers the controller-runtime controller for Deployments
-func SetupDeploymentController(mgr manager.Manager) error {
-	reconciler := &DeploymentReconciler{Client: mgr.GetClient()}
-	c, err := crcontroller.New("deployment-logger", mgr, crcontroller.Options{
+func SetupDeploymentController(mgr manager.Manager, opts DeploymentOptions) error {
+	reconciler := &DeploymentReconciler{
+		Client:   mgr.GetClient(),
+		Recorder: mgr.GetEventRecorderFor("deployment-policy"),
+		Policy:   opts.Policy,
+	}
+	c, err := crcontroller.New("deployment-policy", mgr, crcontroller.Options{
 		Reconciler: reconciler,
 	})
 	if err != nil {
 		return err
 	}
+	// Only Deployments the policy applies to, or that still carry violations
+	// from before they left its scope, are reconciled
+	inScope := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		dep, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			return false
+		}
+		_, violating := dep.Annotations[PolicyViolationsAnnotation]
+		return violating || opts.Policy.Applies(dep)
+	})
 	return c.Watch(
 		source.Kind(mgr.GetCache(), &appsv1.Deployment{}),
 		&DeploymentEventHandler,
+		inScope,
 	)
 }
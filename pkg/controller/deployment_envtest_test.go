@@ -14,11 +14,15 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
 )
 
 func TestDeploymentEventLogging(t *testing.T) {
@@ -56,7 +60,7 @@ func TestDeploymentEventLogging(t *testing.T) {
 	}
 
 	c, err := controller.New("deployment-event-test", mgr, controller.Options{
-		Reconciler: &DeploymentReconciler{Client: mgr.GetClient()},
+		Reconciler: &DeploymentReconciler{Client: mgr.GetClient(), Recorder: mgr.GetEventRecorderFor("deployment-event-test")},
 	})
 	if err != nil {
 		t.Fatalf("failed to build controller: %v", err)
@@ -109,6 +113,64 @@ func TestDeploymentEventLogging(t *testing.T) {
 	}
 }
 
+func TestDeploymentPolicyEnforcement(t *testing.T) {
+	testEnv := &envtest.Environment{}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		t.Fatalf("failed to start envtest: %v", err)
+	}
+	defer func() { _ = testEnv.Stop() }()
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  k8s.NewScheme(),
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	policy := DefaultDeploymentPolicy()
+	policy.AutoRemediate = true
+	if err := SetupDeploymentController(mgr, DeploymentOptions{Policy: policy}); err != nil {
+		t.Fatalf("failed to set up controller: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = mgr.Start(ctx) }()
+
+	k8sClient, err := client.New(cfg, client.Options{Scheme: k8s.NewScheme()})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	deploy := newCompliantDeployment()
+	deploy.Labels["environment"] = "production"
+	deploy.Spec.Template.Spec.Containers[0].Image = "nginx:latest"
+	if err := k8sClient.Create(ctx, deploy); err != nil {
+		t.Fatalf("failed to create deployment: %v", err)
+	}
+
+	// The latest tag is reported; the replica count is remediated
+	key := client.ObjectKeyFromObject(deploy)
+	waitFor(t, func() bool {
+		return k8sClient.Get(ctx, key, deploy) == nil && deploy.Annotations[PolicyViolationsAnnotation] == RuleLatestTag
+	}, "violations annotation")
+	if *deploy.Spec.Replicas != policy.MinProductionReplicas {
+		t.Errorf("expected deployment to be scaled to %d replicas, got %d", policy.MinProductionReplicas, *deploy.Spec.Replicas)
+	}
+	waitFor(t, func() bool {
+		events := &corev1.EventList{}
+		if err := k8sClient.List(ctx, events, client.InNamespace(deploy.Namespace)); err != nil {
+			return false
+		}
+		for _, e := range events.Items {
+			if e.InvolvedObject.Name == deploy.Name && e.Type == corev1.EventTypeWarning && e.Reason == RuleLatestTag {
+				return true
+			}
+		}
+		return false
+	}, "policy violation event")
+}
+
 func waitForEvent(ch <-chan string, want string) error {
 	timeout := time.After(5 * time.Second)
 	for {
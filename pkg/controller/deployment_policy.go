@@ -0,0 +1,245 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Policy rules, used as Event reasons and in the violations annotation
+const (
+	RuleRequiredLabels = "MissingRequiredLabels"
+	RuleResources      = "MissingResources"
+	RuleLatestTag      = "LatestImageTag"
+	RuleMinReplicas    = "InsufficientReplicas"
+)
+
+// PolicyViolationsAnnotation lists the policy rules a Deployment currently violates
+const PolicyViolationsAnnotation = "policy.thegostev.com/violations"
+
+// PolicyEnforceLabel opts a Deployment into the default policy when set to "true"
+const PolicyEnforceLabel = "policy.thegostev.com/enforce"
+
+// ExternallyScaledAnnotation marks a Deployment whose replicas are managed
+// outside the policy when set to "true", so they are never remediated
+const ExternallyScaledAnnotation = "policy.thegostev.com/externally-scaled"
+
+// DeploymentPolicy configures the checks enforced on Deployments
+type DeploymentPolicy struct {
+	// RequiredLabels must be present on every Deployment
+	RequiredLabels []string
+
+	// RequireResources requires CPU and memory requests and limits on every container
+	RequireResources bool
+
+	// DisallowLatestTag rejects images tagged :latest or without a tag or digest
+	DisallowLatestTag bool
+
+	// ProductionSelector selects the Deployments that are production workloads
+	ProductionSelector labels.Selector
+
+	// MinProductionReplicas is the minimum replica count of production workloads
+	MinProductionReplicas int32
+
+	// ExemptNamespaces are never checked
+	ExemptNamespaces []string
+
+	// Namespaces limits the policy to the Deployments of these namespaces;
+	// empty means all namespaces
+	Namespaces []string
+
+	// Selector selects the Deployments the policy applies to; nil or an empty
+	// selector selects all of them
+	Selector labels.Selector
+
+	// AutoRemediate applies safe fixes instead of only reporting violations.
+	// The only safe fix is raising production replicas to the minimum, and only
+	// for Deployments that are not scaled by an HPA or marked with
+	// ExternallyScaledAnnotation; labels, resources and image tags need a human
+	// decision.
+	AutoRemediate bool
+}
+
+// DefaultDeploymentPolicy returns the policy enforced when none is configured
+func DefaultDeploymentPolicy() DeploymentPolicy {
+	return DeploymentPolicy{
+		RequiredLabels:        []string{"app.kubernetes.io/name"},
+		RequireResources:      true,
+		DisallowLatestTag:     true,
+		ProductionSelector:    labels.SelectorFromSet(labels.Set{"environment": "production"}),
+		MinProductionReplicas: 2,
+		ExemptNamespaces:      []string{"kube-system", "kube-public", "kube-node-lease"},
+		Selector:              labels.SelectorFromSet(labels.Set{PolicyEnforceLabel: "true"}),
+	}
+}
+
+// PolicyViolation is a single failed policy check
+type PolicyViolation struct {
+	// Rule is the violated rule, e.g. RuleLatestTag
+	Rule string
+
+	// Message describes the violation
+	Message string
+}
+
+// Exempt reports whether the Deployment is not subject to the policy
+func (p DeploymentPolicy) Exempt(dep *appsv1.Deployment) bool {
+	return containsString(p.ExemptNamespaces, dep.Namespace)
+}
+
+// Applies reports whether the Deployment is selected by the policy and not exempt
+func (p DeploymentPolicy) Applies(dep *appsv1.Deployment) bool {
+	if p.Exempt(dep) {
+		return false
+	}
+	if len(p.Namespaces) > 0 && !containsString(p.Namespaces, dep.Namespace) {
+		return false
+	}
+	return p.Selector == nil || p.Selector.Matches(labels.Set(dep.Labels))
+}
+
+// Check returns the policy violations of a Deployment, at most one per rule
+func (p DeploymentPolicy) Check(dep *appsv1.Deployment) []PolicyViolation {
+	var violations []PolicyViolation
+
+	var missing []string
+	for _, key := range p.RequiredLabels {
+		if _, ok := dep.Labels[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		violations = append(violations, PolicyViolation{
+			Rule:    RuleRequiredLabels,
+			Message: fmt.Sprintf("missing required labels: %s", strings.Join(missing, ", ")),
+		})
+	}
+
+	containers := allContainers(&dep.Spec.Template.Spec)
+	if p.RequireResources {
+		var unbounded []string
+		for _, c := range containers {
+			if !hasResources(c.Resources.Requests) || !hasResources(c.Resources.Limits) {
+				unbounded = append(unbounded, c.Name)
+			}
+		}
+		if len(unbounded) > 0 {
+			violations = append(violations, PolicyViolation{
+				Rule:    RuleResources,
+				Message: fmt.Sprintf("containers without cpu and memory requests and limits: %s", strings.Join(unbounded, ", ")),
+			})
+		}
+	}
+
+	if p.DisallowLatestTag {
+		var latest []string
+		for _, c := range containers {
+			if usesLatestTag(c.Image) {
+				latest = append(latest, fmt.Sprintf("%s (%s)", c.Name, c.Image))
+			}
+		}
+		if len(latest) > 0 {
+			violations = append(violations, PolicyViolation{
+				Rule:    RuleLatestTag,
+				Message: fmt.Sprintf("containers using the latest image tag: %s", strings.Join(latest, ", ")),
+			})
+		}
+	}
+
+	if p.isProduction(dep) && replicas(dep) < p.MinProductionReplicas {
+		violations = append(violations, PolicyViolation{
+			Rule:    RuleMinReplicas,
+			Message: fmt.Sprintf("production workload has %d replicas, at least %d required", replicas(dep), p.MinProductionReplicas),
+		})
+	}
+	return violations
+}
+
+// Remediate applies the safe fixes to the Deployment in place and describes each
+// fix applied. hpas are the HPAs of the Deployment's namespace; replicas are left
+// alone when one of them targets the Deployment.
+func (p DeploymentPolicy) Remediate(dep *appsv1.Deployment, hpas []autoscalingv2.HorizontalPodAutoscaler) []string {
+	var fixes []string
+	if p.isProduction(dep) && replicas(dep) < p.MinProductionReplicas && !externallyScaled(dep, hpas) {
+		fixes = append(fixes, fmt.Sprintf("scaled from %d to %d replicas", replicas(dep), p.MinProductionReplicas))
+		minReplicas := p.MinProductionReplicas
+		dep.Spec.Replicas = &minReplicas
+	}
+	return fixes
+}
+
+func (p DeploymentPolicy) isProduction(dep *appsv1.Deployment) bool {
+	return p.ProductionSelector != nil && !p.ProductionSelector.Empty() &&
+		p.ProductionSelector.Matches(labels.Set(dep.Labels))
+}
+
+// externallyScaled reports whether the replicas of the Deployment are managed by
+// one of hpas or marked with ExternallyScaledAnnotation
+func externallyScaled(dep *appsv1.Deployment, hpas []autoscalingv2.HorizontalPodAutoscaler) bool {
+	if dep.Annotations[ExternallyScaledAnnotation] == "true" {
+		return true
+	}
+	for _, hpa := range hpas {
+		target := hpa.Spec.ScaleTargetRef
+		if hpa.Namespace == dep.Namespace && target.Kind == "Deployment" && target.Name == dep.Name &&
+			strings.HasPrefix(target.APIVersion, appsv1.GroupName+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// violationRules returns the sorted rule names of violations, as stored in PolicyViolationsAnnotation
+func violationRules(violations []PolicyViolation) string {
+	rules := make([]string, 0, len(violations))
+	for _, v := range violations {
+		rules = append(rules, v.Rule)
+	}
+	sort.Strings(rules)
+	return strings.Join(rules, ",")
+}
+
+// replicas returns the desired replica count, which the API server defaults to 1
+func replicas(dep *appsv1.Deployment) int32 {
+	if dep.Spec.Replicas == nil {
+		return 1
+	}
+	return *dep.Spec.Replicas
+}
+
+func allContainers(spec *corev1.PodSpec) []corev1.Container {
+	containers := make([]corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	containers = append(containers, spec.InitContainers...)
+	return append(containers, spec.Containers...)
+}
+
+func hasResources(list corev1.ResourceList) bool {
+	_, cpu := list[corev1.ResourceCPU]
+	_, memory := list[corev1.ResourceMemory]
+	return cpu && memory
+}
+
+// usesLatestTag reports whether an image resolves to the latest tag: it is tagged
+// :latest, or has neither a tag nor a digest
+func usesLatestTag(image string) bool {
+	if strings.Contains(image, "@") {
+		return false
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	i := strings.LastIndex(name, ":")
+	return i < 0 || name[i+1:] == "latest"
+}
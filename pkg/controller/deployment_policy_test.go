@@ -0,0 +1,219 @@
+package controller
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// newCompliantDeployment returns a Deployment satisfying DefaultDeploymentPolicy
+func newCompliantDeployment() *appsv1.Deployment {
+	replicas := int32(1)
+	bounded := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("64Mi"),
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+			Labels:    map[string]string{"app.kubernetes.io/name": "web", PolicyEnforceLabel: "true"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Name:      "web",
+					Image:     "nginx:1.25",
+					Resources: corev1.ResourceRequirements{Requests: bounded, Limits: bounded},
+				}}},
+			},
+		},
+	}
+}
+
+func TestDeploymentPolicyCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(dep *appsv1.Deployment)
+		want   string
+	}{
+		{
+			name:   "compliant",
+			mutate: func(dep *appsv1.Deployment) {},
+			want:   "",
+		},
+		{
+			name:   "missing label",
+			mutate: func(dep *appsv1.Deployment) { dep.Labels = nil },
+			want:   RuleRequiredLabels,
+		},
+		{
+			name: "missing limits",
+			mutate: func(dep *appsv1.Deployment) {
+				dep.Spec.Template.Spec.Containers[0].Resources.Limits = nil
+			},
+			want: RuleResources,
+		},
+		{
+			name: "init container without resources",
+			mutate: func(dep *appsv1.Deployment) {
+				dep.Spec.Template.Spec.InitContainers = []corev1.Container{{Name: "init", Image: "busybox:1.36"}}
+			},
+			want: RuleResources,
+		},
+		{
+			name:   "latest tag",
+			mutate: func(dep *appsv1.Deployment) { dep.Spec.Template.Spec.Containers[0].Image = "nginx:latest" },
+			want:   RuleLatestTag,
+		},
+		{
+			name:   "untagged image",
+			mutate: func(dep *appsv1.Deployment) { dep.Spec.Template.Spec.Containers[0].Image = "registry:5000/nginx" },
+			want:   RuleLatestTag,
+		},
+		{
+			name: "production with too few replicas",
+			mutate: func(dep *appsv1.Deployment) {
+				dep.Labels["environment"] = "production"
+			},
+			want: RuleMinReplicas,
+		},
+		{
+			name: "several violations",
+			mutate: func(dep *appsv1.Deployment) {
+				dep.Labels = map[string]string{"environment": "production"}
+				dep.Spec.Template.Spec.Containers[0].Image = "nginx"
+			},
+			want: RuleMinReplicas + "," + RuleLatestTag + "," + RuleRequiredLabels,
+		},
+	}
+
+	policy := DefaultDeploymentPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dep := newCompliantDeployment()
+			tt.mutate(dep)
+			if got := violationRules(policy.Check(dep)); got != tt.want {
+				t.Errorf("expected violations %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUsesLatestTag(t *testing.T) {
+	for image, want := range map[string]bool{
+		"nginx":                          true,
+		"nginx:latest":                   true,
+		"localhost:5000/nginx":           true,
+		"localhost:5000/nginx:latest":    true,
+		"nginx:1.25":                     false,
+		"localhost:5000/nginx:1.25":      false,
+		"nginx@sha256:0123456789abcdef":  false,
+		"ghcr.io/org/app:latest-release": false,
+	} {
+		if got := usesLatestTag(image); got != want {
+			t.Errorf("usesLatestTag(%q) = %v, want %v", image, got, want)
+		}
+	}
+}
+
+func TestDeploymentPolicyRemediate(t *testing.T) {
+	policy := DefaultDeploymentPolicy()
+	dep := newCompliantDeployment()
+	dep.Labels["environment"] = "production"
+
+	if fixes := policy.Remediate(dep, nil); len(fixes) != 1 {
+		t.Fatalf("expected one fix, got %v", fixes)
+	}
+	if *dep.Spec.Replicas != policy.MinProductionReplicas {
+		t.Errorf("expected %d replicas, got %d", policy.MinProductionReplicas, *dep.Spec.Replicas)
+	}
+	if violations := policy.Check(dep); len(violations) != 0 {
+		t.Errorf("expected no violations after remediation, got %v", violations)
+	}
+	if fixes := policy.Remediate(dep, nil); len(fixes) != 0 {
+		t.Errorf("expected remediation to be idempotent, got %v", fixes)
+	}
+}
+
+// newHPA returns an HPA in namespace default scaling the target
+func newHPA(apiVersion, kind, name string) autoscalingv2.HorizontalPodAutoscaler {
+	return autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: apiVersion, Kind: kind, Name: name},
+			MaxReplicas:    5,
+		},
+	}
+}
+
+func TestDeploymentPolicyRemediateSkipsExternallyScaled(t *testing.T) {
+	tests := []struct {
+		name      string
+		hpas      []autoscalingv2.HorizontalPodAutoscaler
+		annotated bool
+		wantFixes int
+	}{
+		{name: "hpa targeting the deployment", hpas: []autoscalingv2.HorizontalPodAutoscaler{newHPA("apps/v1", "Deployment", "web")}},
+		{name: "externally scaled annotation", annotated: true},
+		{name: "hpa targeting another deployment", hpas: []autoscalingv2.HorizontalPodAutoscaler{newHPA("apps/v1", "Deployment", "api")}, wantFixes: 1},
+		{name: "hpa targeting a statefulset", hpas: []autoscalingv2.HorizontalPodAutoscaler{newHPA("apps/v1", "StatefulSet", "web")}, wantFixes: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := DefaultDeploymentPolicy()
+			dep := newCompliantDeployment()
+			dep.Labels["environment"] = "production"
+			if tt.annotated {
+				dep.Annotations = map[string]string{ExternallyScaledAnnotation: "true"}
+			}
+			if fixes := policy.Remediate(dep, tt.hpas); len(fixes) != tt.wantFixes {
+				t.Errorf("expected %d fixes, got %v", tt.wantFixes, fixes)
+			}
+		})
+	}
+}
+
+func TestDeploymentPolicyApplies(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(policy *DeploymentPolicy, dep *appsv1.Deployment)
+		want   bool
+	}{
+		{name: "opted in", mutate: func(*DeploymentPolicy, *appsv1.Deployment) {}, want: true},
+		{name: "not opted in", mutate: func(_ *DeploymentPolicy, dep *appsv1.Deployment) {
+			delete(dep.Labels, PolicyEnforceLabel)
+		}, want: false},
+		{name: "exempt namespace", mutate: func(_ *DeploymentPolicy, dep *appsv1.Deployment) {
+			dep.Namespace = "kube-system"
+		}, want: false},
+		{name: "outside the policy namespaces", mutate: func(policy *DeploymentPolicy, _ *appsv1.Deployment) {
+			policy.Namespaces = []string{"shop"}
+		}, want: false},
+		{name: "inside the policy namespaces", mutate: func(policy *DeploymentPolicy, _ *appsv1.Deployment) {
+			policy.Namespaces = []string{"shop", "default"}
+		}, want: true},
+		{name: "empty selector", mutate: func(policy *DeploymentPolicy, dep *appsv1.Deployment) {
+			policy.Selector = labels.Everything()
+			dep.Labels = nil
+		}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := DefaultDeploymentPolicy()
+			dep := newCompliantDeployment()
+			tt.mutate(&policy, dep)
+			if got := policy.Applies(dep); got != tt.want {
+				t.Errorf("expected Applies = %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+)
+
+// drainEvents returns the events recorded so far
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestDeploymentReconcileReportsViolations(t *testing.T) {
+	dep := newCompliantDeployment()
+	dep.Spec.Template.Spec.Containers[0].Image = "nginx:latest"
+	c := fake.NewClientBuilder().WithScheme(k8s.NewScheme()).WithObjects(dep).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &DeploymentReconciler{Client: c, Recorder: recorder, Policy: DefaultDeploymentPolicy()}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dep)}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	got := &appsv1.Deployment{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	if got.Annotations[PolicyViolationsAnnotation] != RuleLatestTag {
+		t.Errorf("expected %s annotation %q, got %q", PolicyViolationsAnnotation, RuleLatestTag, got.Annotations[PolicyViolationsAnnotation])
+	}
+	events := drainEvents(recorder)
+	if len(events) != 1 || !strings.HasPrefix(events[0], "Warning "+RuleLatestTag) {
+		t.Errorf("expected one %s warning event, got %v", RuleLatestTag, events)
+	}
+
+	// Unchanged violations are not reported again
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if events := drainEvents(recorder); len(events) != 0 {
+		t.Errorf("expected no new events, got %v", events)
+	}
+
+	// Fixing the image clears the annotation
+	got.Spec.Template.Spec.Containers[0].Image = "nginx:1.25"
+	if err := c.Update(ctx, got); err != nil {
+		t.Fatalf("failed to update deployment: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	if _, ok := got.Annotations[PolicyViolationsAnnotation]; ok {
+		t.Errorf("expected %s annotation to be removed", PolicyViolationsAnnotation)
+	}
+	events = drainEvents(recorder)
+	if len(events) != 1 || !strings.HasPrefix(events[0], "Normal PolicyCompliant") {
+		t.Errorf("expected a PolicyCompliant event, got %v", events)
+	}
+}
+
+func TestDeploymentReconcileAutoRemediates(t *testing.T) {
+	dep := newCompliantDeployment()
+	dep.Labels["environment"] = "production"
+	c := fake.NewClientBuilder().WithScheme(k8s.NewScheme()).WithObjects(dep).Build()
+	recorder := record.NewFakeRecorder(10)
+	policy := DefaultDeploymentPolicy()
+	policy.AutoRemediate = true
+	r := &DeploymentReconciler{Client: c, Recorder: recorder, Policy: policy}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dep)}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	got := &appsv1.Deployment{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	if *got.Spec.Replicas != policy.MinProductionReplicas {
+		t.Errorf("expected deployment to be scaled to %d replicas, got %d", policy.MinProductionReplicas, *got.Spec.Replicas)
+	}
+	if _, ok := got.Annotations[PolicyViolationsAnnotation]; ok {
+		t.Errorf("expected no violations after remediation, got %q", got.Annotations[PolicyViolationsAnnotation])
+	}
+	events := drainEvents(recorder)
+	if len(events) != 1 || !strings.HasPrefix(events[0], "Normal PolicyRemediated") {
+		t.Errorf("expected a PolicyRemediated event, got %v", events)
+	}
+}
+
+func TestDeploymentReconcileSkipsExemptNamespaces(t *testing.T) {
+	dep := newCompliantDeployment()
+	dep.Namespace = "kube-system"
+	dep.Labels = nil
+	c := fake.NewClientBuilder().WithScheme(k8s.NewScheme()).WithObjects(dep).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &DeploymentReconciler{Client: c, Recorder: recorder, Policy: DefaultDeploymentPolicy()}
+
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dep)}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if events := drainEvents(recorder); len(events) != 0 {
+		t.Errorf("expected no events for an exempt namespace, got %v", events)
+	}
+}
+
+func TestDeploymentReconcileSkipsReplicasOfAutoscaledDeployments(t *testing.T) {
+	dep := newCompliantDeployment()
+	dep.Labels["environment"] = "production"
+	hpa := newHPA("apps/v1", "Deployment", dep.Name)
+	c := fake.NewClientBuilder().WithScheme(k8s.NewScheme()).WithObjects(dep, &hpa).Build()
+	policy := DefaultDeploymentPolicy()
+	policy.AutoRemediate = true
+	r := &DeploymentReconciler{Client: c, Recorder: record.NewFakeRecorder(10), Policy: policy}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dep)}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	got := &appsv1.Deployment{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	if *got.Spec.Replicas != 1 {
+		t.Errorf("expected the HPA to keep managing replicas, got %d", *got.Spec.Replicas)
+	}
+	if got.Annotations[PolicyViolationsAnnotation] != RuleMinReplicas {
+		t.Errorf("expected the replica violation to be reported, got %q", got.Annotations[PolicyViolationsAnnotation])
+	}
+}
+
+func TestDeploymentReconcileClearsViolationsOutOfScope(t *testing.T) {
+	dep := newCompliantDeployment()
+	dep.Labels = nil
+	dep.Annotations = map[string]string{PolicyViolationsAnnotation: RuleRequiredLabels}
+	c := fake.NewClientBuilder().WithScheme(k8s.NewScheme()).WithObjects(dep).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &DeploymentReconciler{Client: c, Recorder: recorder, Policy: DefaultDeploymentPolicy()}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dep)}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	got := &appsv1.Deployment{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	if _, ok := got.Annotations[PolicyViolationsAnnotation]; ok {
+		t.Errorf("expected the violations of a deployment out of scope to be removed")
+	}
+	if events := drainEvents(recorder); len(events) != 0 {
+		t.Errorf("expected no events for a deployment out of scope, got %v", events)
+	}
+}
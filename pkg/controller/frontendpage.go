@@ -2,29 +2,66 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/render"
 )
 
-// FrontendPageReconciler logs reconcile requests for FrontendPages
-// (follows same pattern as DeploymentReconciler)
+// FrontendPageReconciler materializes FrontendPages into a ConfigMap holding the
+// rendered page, a Deployment serving it and a Service exposing it
 type FrontendPageReconciler struct {
 	client.Client
+	Scheme *runtime.Scheme
+
+	// Renderer renders the page spec to HTML (defaults to render.NewRenderer())
+	Renderer *render.Renderer
+
+	// PageServerImage is the image run by the child Deployment (defaults to DefaultPageServerImage)
+	PageServerImage string
+
+	// CleanupHooks run before a deleted FrontendPage's finalizer is removed
+	CleanupHooks []CleanupHook
 }
 
+// FrontendPageOptions configures the FrontendPage controller
+type FrontendPageOptions struct {
+	// Renderer is shared with the page server so both render pages identically
+	Renderer *render.Renderer
+
+	// PageServerImage is the image run by the child Deployment
+	PageServerImage string
+
+	// CleanupHooks run before a deleted FrontendPage's finalizer is removed
+	CleanupHooks []CleanupHook
+}
+
+//+kubebuilder:rbac:groups=frontend.thegostev.com,resources=frontendpages,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=frontend.thegostev.com,resources=frontendpages/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=frontend.thegostev.com,resources=frontendpages/finalizers,verbs=update
+//+kubebuilder:rbac:groups=core,resources=configmaps;services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+
 func (r *FrontendPageReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Reconciling FrontendPage", "namespace", req.Namespace, "name", req.Name)
@@ -36,12 +73,24 @@ func (r *FrontendPageReconciler) Reconcile(ctx context.Context, req reconcile.Re
 			logger.Error(err, "failed to get FrontendPage")
 			return reconcile.Result{}, err
 		}
-		// Resource not found, likely deleted
+		// Resource not found; cleanup already ran and owned objects are garbage-collected
 		logger.Info("FrontendPage not found, likely deleted")
 		return reconcile.Result{}, nil
 	}
 
-	// Simple reconciliation logic (following existing pattern)
+	if !frontendPage.DeletionTimestamp.IsZero() {
+		if err := r.finalize(ctx, frontendPage); err != nil {
+			logger.Error(err, "failed to finalize FrontendPage")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.ensureFinalizer(ctx, frontendPage); err != nil {
+		logger.Error(err, "failed to add finalizer to FrontendPage")
+		return reconcile.Result{}, err
+	}
+
 	if err := r.reconcileFrontendPage(ctx, frontendPage); err != nil {
 		logger.Error(err, "failed to reconcile FrontendPage")
 		return reconcile.Result{}, err
@@ -53,27 +102,152 @@ func (r *FrontendPageReconciler) Reconcile(ctx context.Context, req reconcile.Re
 // reconcileFrontendPage performs the actual reconciliation
 func (r *FrontendPageReconciler) reconcileFrontendPage(ctx context.Context, frontendPage *v1alpha1.FrontendPage) error {
 	logger := log.FromContext(ctx)
+	original := frontendPage.DeepCopy()
 
-	// Update status to show reconciliation
-	frontendPage.Status.Phase = "Ready"
-	frontendPage.Status.Message = "Frontend page is ready"
-	frontendPage.Status.ComponentCount = len(frontendPage.Spec.Components)
-	frontendPage.Status.URL = fmt.Sprintf("http://localhost:8080/pages/%s/%s", frontendPage.Namespace, frontendPage.Name)
-	frontendPage.Status.LastUpdated = &metav1.Time{Time: time.Now()}
+	// Reject specs that cannot be rendered (e.g. unknown component types or invalid
+	// configs) before touching any children; the admission webhook enforces the same rules
+	if errs := r.renderer().Validate(frontendPage); len(errs) > 0 {
+		logger.Info("FrontendPage spec is invalid", "errors", errs.ToAggregate().Error())
+		setInvalidSpecConditions(frontendPage, errs.ToAggregate().Error())
+		return r.updateStatus(ctx, frontendPage, original)
+	}
 
-	if err := r.Status().Update(ctx, frontendPage); err != nil {
-		logger.Error(err, "failed to update FrontendPage status")
+	html, err := r.renderer().RenderString(frontendPage)
+	if err != nil {
+		err = fmt.Errorf("failed to render page: %w", err)
+		setRenderFailedConditions(frontendPage, err)
+		return errors.Join(err, r.updateStatus(ctx, frontendPage, original))
+	}
+	setRenderedCondition(frontendPage)
+
+	deployment, service, err := r.reconcileChildren(ctx, frontendPage, html)
+	if err != nil {
+		setReconcileFailedConditions(frontendPage, err)
+		return errors.Join(err, r.updateStatus(ctx, frontendPage, original))
+	}
+
+	// Only report Ready once the page server is actually available
+	setDeploymentConditions(frontendPage, deployment, serviceURL(service))
+	frontendPage.Status.URL = serviceURL(service)
+
+	if err := r.updateStatus(ctx, frontendPage, original); err != nil {
 		return err
 	}
 
 	logger.Info("FrontendPage reconciled successfully",
 		"namespace", frontendPage.Namespace,
 		"name", frontendPage.Name,
-		"components", len(frontendPage.Spec.Components))
+		"components", len(frontendPage.Spec.Components),
+		"phase", frontendPage.Status.Phase)
+
+	return nil
+}
+
+// reconcileChildren ensures the ConfigMap, Deployment and Service owned by the page are up to date
+func (r *FrontendPageReconciler) reconcileChildren(ctx context.Context, frontendPage *v1alpha1.FrontendPage, html string) (*appsv1.Deployment, *corev1.Service, error) {
+	configMap, err := r.reconcileConfigMap(ctx, frontendPage, html)
+	if err != nil {
+		return nil, nil, err
+	}
+	deployment, err := r.reconcileDeployment(ctx, frontendPage, configMap.Name, contentHash(html))
+	if err != nil {
+		return nil, nil, err
+	}
+	service, err := r.reconcileService(ctx, frontendPage)
+	if err != nil {
+		return nil, nil, err
+	}
+	return deployment, service, nil
+}
 
+// updateStatus fills in the fields derived from the conditions and patches the
+// status only when it differs from the status the reconcile started with. Skipping
+// no-op writes keeps status updates from re-triggering reconciles.
+func (r *FrontendPageReconciler) updateStatus(ctx context.Context, frontendPage, original *v1alpha1.FrontendPage) error {
+	status := &frontendPage.Status
+	status.ObservedGeneration = frontendPage.Generation
+	status.ComponentCount = len(frontendPage.Spec.Components)
+	status.Phase = derivePhase(status.Conditions)
+	if ready := meta.FindStatusCondition(status.Conditions, v1alpha1.ConditionReady); ready != nil {
+		status.Message = ready.Message
+	}
+
+	if statusEqual(&original.Status, status) {
+		log.FromContext(ctx).V(1).Info("FrontendPage status unchanged, skipping update")
+		return nil
+	}
+	status.LastUpdated = &metav1.Time{Time: time.Now()}
+
+	if err := r.Status().Patch(ctx, frontendPage, client.MergeFrom(original)); err != nil {
+		log.FromContext(ctx).Error(err, "failed to patch FrontendPage status")
+		return err
+	}
 	return nil
 }
 
+// statusEqual compares two statuses ignoring LastUpdated, which only records when a change was written
+func statusEqual(a, b *v1alpha1.FrontendPageStatus) bool {
+	a, b = a.DeepCopy(), b.DeepCopy()
+	a.LastUpdated, b.LastUpdated = nil, nil
+	return equality.Semantic.DeepEqual(a, b)
+}
+
+// defaultRenderer is used by reconcilers constructed without a Renderer
+var defaultRenderer = render.NewRenderer()
+
+func (r *FrontendPageReconciler) renderer() *render.Renderer {
+	if r.Renderer != nil {
+		return r.Renderer
+	}
+	return defaultRenderer
+}
+
+// reconcileConfigMap ensures the ConfigMap holding the rendered page exists and is up to date
+func (r *FrontendPageReconciler) reconcileConfigMap(ctx context.Context, frontendPage *v1alpha1.FrontendPage, html string) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: childName(frontendPage), Namespace: frontendPage.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		mutateConfigMap(cm, frontendPage, html)
+		return controllerutil.SetControllerReference(frontendPage, cm, r.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile configmap %s: %w", cm.Name, err)
+	}
+	log.FromContext(ctx).V(1).Info("ConfigMap reconciled", "name", cm.Name, "operation", op)
+	return cm, nil
+}
+
+// reconcileDeployment ensures the Deployment serving the rendered page exists and is up to date
+func (r *FrontendPageReconciler) reconcileDeployment(ctx context.Context, frontendPage *v1alpha1.FrontendPage, configMapName, hash string) (*appsv1.Deployment, error) {
+	image := r.PageServerImage
+	if image == "" {
+		image = DefaultPageServerImage
+	}
+	dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: childName(frontendPage), Namespace: frontendPage.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, dep, func() error {
+		mutateDeployment(dep, frontendPage, configMapName, hash, image)
+		return controllerutil.SetControllerReference(frontendPage, dep, r.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile deployment %s: %w", dep.Name, err)
+	}
+	log.FromContext(ctx).V(1).Info("Deployment reconciled", "name", dep.Name, "operation", op)
+	return dep, nil
+}
+
+// reconcileService ensures the Service exposing the page server exists and is up to date
+func (r *FrontendPageReconciler) reconcileService(ctx context.Context, frontendPage *v1alpha1.FrontendPage) (*corev1.Service, error) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: childName(frontendPage), Namespace: frontendPage.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, svc, func() error {
+		mutateService(svc, frontendPage)
+		return controllerutil.SetControllerReference(frontendPage, svc, r.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile service %s: %w", svc.Name, err)
+	}
+	log.FromContext(ctx).V(1).Info("Service reconciled", "name", svc.Name, "operation", op)
+	return svc, nil
+}
+
 // FrontendPageEventHandler logs all FrontendPage events
 // (follows exact same pattern as DeploymentEventHandler)
 var FrontendPageEventHandler = handler.Funcs{
@@ -96,17 +270,37 @@ var FrontendPageEventHandler = handler.Funcs{
 }
 
 // SetupFrontendPageController registers the controller-runtime controller for FrontendPages
-// (follows exact same pattern as SetupDeploymentController)
-func SetupFrontendPageController(mgr manager.Manager) error {
-	reconciler := &FrontendPageReconciler{Client: mgr.GetClient()}
-	c, err := crcontroller.New("frontendpage-logger", mgr, crcontroller.Options{
+// and the objects they own
+func SetupFrontendPageController(mgr manager.Manager, opts FrontendPageOptions) error {
+	reconciler := &FrontendPageReconciler{
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		Renderer:        opts.Renderer,
+		PageServerImage: opts.PageServerImage,
+		CleanupHooks:    opts.CleanupHooks,
+	}
+	c, err := crcontroller.New("frontendpage", mgr, crcontroller.Options{
 		Reconciler: reconciler,
 	})
 	if err != nil {
 		return err
 	}
-	return c.Watch(
+	// Only spec changes bump the generation, so status and metadata-only updates
+	// (including the controller's own status patches) do not re-enqueue the page
+	if err := c.Watch(
 		source.Kind(mgr.GetCache(), &v1alpha1.FrontendPage{}),
 		&FrontendPageEventHandler,
-	)
+		predicate.GenerationChangedPredicate{},
+	); err != nil {
+		return err
+	}
+
+	// Re-reconcile the owning FrontendPage whenever one of its children changes
+	ownerHandler := handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &v1alpha1.FrontendPage{}, handler.OnlyControllerOwner())
+	for _, owned := range []client.Object{&corev1.ConfigMap{}, &appsv1.Deployment{}, &corev1.Service{}} {
+		if err := c.Watch(source.Kind(mgr.GetCache(), owned), ownerHandler); err != nil {
+			return err
+		}
+	}
+	return nil
 }
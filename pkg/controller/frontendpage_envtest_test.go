@@ -0,0 +1,185 @@
+//go:build integration
+
+package controller
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/webhook"
+)
+
+func TestFrontendPageReconcileDoesNotLoop(t *testing.T) {
+	cfg, mgr := newTestManager(t)
+	if err := SetupFrontendPageController(mgr, FrontendPageOptions{}); err != nil {
+		t.Fatalf("failed to set up controller: %v", err)
+	}
+
+	ctx := startTestManager(t, mgr)
+
+	k8sClient, err := client.New(cfg, client.Options{Scheme: k8s.NewScheme()})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	page := &v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "loop", Namespace: "default"},
+		Spec: v1alpha1.FrontendPageSpec{
+			Title:      "Loop",
+			Template:   "dashboard",
+			Components: []v1alpha1.Component{{Name: "trend", Type: "chart"}},
+		},
+	}
+	if err := k8sClient.Create(ctx, page); err != nil {
+		t.Fatalf("failed to create FrontendPage: %v", err)
+	}
+
+	// Wait for the controller to report the initial status
+	key := client.ObjectKeyFromObject(page)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if err := k8sClient.Get(ctx, key, page); err != nil {
+			t.Fatalf("failed to get FrontendPage: %v", err)
+		}
+		if page.Status.ObservedGeneration == page.Generation && page.Status.Phase != "" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("status was never reported")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// envtest runs no deployment controller, so nothing should change from here on.
+	// A reconcile loop would keep bumping the resourceVersion through status writes.
+	settled := page.ResourceVersion
+	time.Sleep(3 * time.Second)
+	if err := k8sClient.Get(ctx, key, page); err != nil {
+		t.Fatalf("failed to get FrontendPage: %v", err)
+	}
+	if page.ResourceVersion != settled {
+		t.Fatalf("FrontendPage kept changing after settling: resourceVersion %s -> %s", settled, page.ResourceVersion)
+	}
+}
+
+func TestFrontendPageFinalizerCleanup(t *testing.T) {
+	cfg, mgr := newTestManager(t)
+
+	// The first cleanup attempt fails to prove the finalizer is kept and cleanup retried
+	var calls atomic.Int32
+	hook := CleanupFunc(func(ctx context.Context, page *v1alpha1.FrontendPage) error {
+		if calls.Add(1) == 1 {
+			return apierrors.NewServiceUnavailable("registry unavailable")
+		}
+		return nil
+	})
+	if err := SetupFrontendPageController(mgr, FrontendPageOptions{CleanupHooks: []CleanupHook{hook}}); err != nil {
+		t.Fatalf("failed to set up controller: %v", err)
+	}
+
+	ctx := startTestManager(t, mgr)
+
+	k8sClient, err := client.New(cfg, client.Options{Scheme: k8s.NewScheme()})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	page := &v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "finalized", Namespace: "default"},
+		Spec: v1alpha1.FrontendPageSpec{
+			Title:      "Finalized",
+			Template:   "dashboard",
+			Components: []v1alpha1.Component{{Name: "trend", Type: "chart"}},
+		},
+	}
+	if err := k8sClient.Create(ctx, page); err != nil {
+		t.Fatalf("failed to create FrontendPage: %v", err)
+	}
+
+	key := client.ObjectKeyFromObject(page)
+	waitFor(t, func() bool {
+		return k8sClient.Get(ctx, key, page) == nil && controllerutil.ContainsFinalizer(page, FrontendPageFinalizer)
+	}, "finalizer to be added")
+
+	if err := k8sClient.Delete(ctx, page); err != nil {
+		t.Fatalf("failed to delete FrontendPage: %v", err)
+	}
+	waitFor(t, func() bool {
+		return apierrors.IsNotFound(k8sClient.Get(ctx, key, &v1alpha1.FrontendPage{}))
+	}, "FrontendPage to be deleted")
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected cleanup to run twice (one failure, one success), ran %d times", got)
+	}
+}
+
+// newTestManager starts envtest with the FrontendPage CRD and returns a manager serving the
+// conversion webhook envtest configures for it
+func newTestManager(t *testing.T) (*rest.Config, manager.Manager) {
+	t.Helper()
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+		Scheme:                k8s.NewScheme(),
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		t.Fatalf("failed to start envtest: %v", err)
+	}
+	t.Cleanup(func() { _ = testEnv.Stop() })
+
+	opts := testEnv.WebhookInstallOptions
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  k8s.NewScheme(),
+		Metrics: metricsserver.Options{BindAddress: "0"},
+		WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
+			Host:    opts.LocalServingHost,
+			Port:    opts.LocalServingPort,
+			CertDir: opts.LocalServingCertDir,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	if err := webhook.SetupFrontendPageConversionWebhook(mgr); err != nil {
+		t.Fatalf("failed to set up conversion webhook: %v", err)
+	}
+	return cfg, mgr
+}
+
+// startTestManager runs the manager until the test ends and waits for its webhook server
+func startTestManager(t *testing.T, mgr manager.Manager) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = mgr.Start(ctx) }()
+
+	started := mgr.GetWebhookServer().StartedChecker()
+	waitFor(t, func() bool { return started(nil) == nil }, "webhook server to start")
+	return ctx
+}
+
+func waitFor(t *testing.T, cond func() bool, what string) {
+	t.Helper()
+	deadline := time.Now().Add(30 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
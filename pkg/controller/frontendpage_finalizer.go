@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+)
+
+// FrontendPageFinalizer keeps a FrontendPage around until its cleanup hooks have run
+const FrontendPageFinalizer = "frontend.thegostev.com/finalizer"
+
+// CleanupHook releases state that owner references cannot reach when a FrontendPage
+// is deleted, such as cluster-scoped objects, page server routes or entries in
+// external registries. Hooks must be idempotent: they run again if any hook fails.
+type CleanupHook interface {
+	Cleanup(ctx context.Context, page *v1alpha1.FrontendPage) error
+}
+
+// CleanupFunc adapts a function to the CleanupHook interface
+type CleanupFunc func(ctx context.Context, page *v1alpha1.FrontendPage) error
+
+// Cleanup calls f(ctx, page)
+func (f CleanupFunc) Cleanup(ctx context.Context, page *v1alpha1.FrontendPage) error {
+	return f(ctx, page)
+}
+
+// ensureFinalizer adds the finalizer to a live FrontendPage
+func (r *FrontendPageReconciler) ensureFinalizer(ctx context.Context, frontendPage *v1alpha1.FrontendPage) error {
+	original := frontendPage.DeepCopy()
+	if !controllerutil.AddFinalizer(frontendPage, FrontendPageFinalizer) {
+		return nil
+	}
+	if err := r.Patch(ctx, frontendPage, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("failed to add finalizer: %w", err)
+	}
+	log.FromContext(ctx).V(1).Info("Added finalizer to FrontendPage", "finalizer", FrontendPageFinalizer)
+	return nil
+}
+
+// finalize runs the cleanup hooks of a FrontendPage being deleted and removes the
+// finalizer once all of them succeed. It is a no-op once the finalizer is gone.
+func (r *FrontendPageReconciler) finalize(ctx context.Context, frontendPage *v1alpha1.FrontendPage) error {
+	logger := log.FromContext(ctx)
+	if !controllerutil.ContainsFinalizer(frontendPage, FrontendPageFinalizer) {
+		return nil
+	}
+
+	logger.Info("Running FrontendPage cleanup hooks", "hooks", len(r.CleanupHooks))
+	var errs []error
+	for _, hook := range r.CleanupHooks {
+		if err := hook.Cleanup(ctx, frontendPage); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("cleanup failed, keeping finalizer: %w", err)
+	}
+
+	original := frontendPage.DeepCopy()
+	controllerutil.RemoveFinalizer(frontendPage, FrontendPageFinalizer)
+	if err := r.Patch(ctx, frontendPage, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		return client.IgnoreNotFound(fmt.Errorf("failed to remove finalizer: %w", err))
+	}
+	logger.Info("Removed finalizer from FrontendPage", "finalizer", FrontendPageFinalizer)
+	return nil
+}
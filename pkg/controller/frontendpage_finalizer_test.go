@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+)
+
+func TestFrontendPageFinalizer(t *testing.T) {
+	scheme := k8s.NewScheme()
+	page := newTestFrontendPage()
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(page).
+		WithStatusSubresource(&v1alpha1.FrontendPage{}).
+		Build()
+
+	calls := 0
+	failing := true
+	hook := CleanupFunc(func(ctx context.Context, page *v1alpha1.FrontendPage) error {
+		calls++
+		if failing {
+			return errors.New("registry unavailable")
+		}
+		return nil
+	})
+	r := &FrontendPageReconciler{Client: c, Scheme: scheme, CleanupHooks: []CleanupHook{hook}}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(page)}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	got := &v1alpha1.FrontendPage{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("failed to get FrontendPage: %v", err)
+	}
+	if !controllerutil.ContainsFinalizer(got, FrontendPageFinalizer) {
+		t.Fatalf("expected finalizer to be added, got %v", got.Finalizers)
+	}
+
+	// Deleting marks the page for deletion; a failing hook keeps the finalizer
+	if err := c.Delete(ctx, got); err != nil {
+		t.Fatalf("failed to delete FrontendPage: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err == nil {
+		t.Fatalf("expected reconcile to fail while cleanup fails")
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("expected FrontendPage to be kept while cleanup fails: %v", err)
+	}
+
+	// Once the hook succeeds the finalizer is removed and the page goes away
+	failing = false
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected FrontendPage to be gone, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected cleanup to run twice, ran %d times", calls)
+	}
+
+	// Reconciling a page that is already gone does not run cleanup again
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected cleanup not to run again, ran %d times", calls)
+	}
+}
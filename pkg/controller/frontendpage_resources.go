@@ -0,0 +1,220 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+)
+
+const (
+	// DefaultPageServerImage is the image used to serve rendered pages
+	DefaultPageServerImage = "nginx:1.25-alpine"
+
+	pageServerContainerName = "page-server"
+	pageServerPort          = 80
+	pageContentVolumeName   = "page-content"
+	pageContentMountPath    = "/usr/share/nginx/html"
+	pageIndexKey            = "index.html"
+
+	// configHashAnnotation rolls the page server pods whenever the rendered content changes
+	configHashAnnotation = "frontend.thegostev.com/config-hash"
+)
+
+// childName returns the name shared by all objects owned by a FrontendPage,
+// "frontendpage-<name>". The Service name must be a DNS-1035 label, so for page
+// names that are too long or contain dots the name is shortened and made unique
+// with a hash of the page name.
+func childName(page *v1alpha1.FrontendPage) string {
+	name := "frontendpage-" + page.Name
+	if len(validation.IsDNS1035Label(name)) == 0 {
+		return name
+	}
+	return truncateWithHash(strings.ReplaceAll(name, ".", "-"), page.Name, validation.DNS1035LabelMaxLength)
+}
+
+// childLabels returns the labels applied to all objects owned by a FrontendPage.
+// The instance label holds the page name, shortened like childName when it
+// exceeds the label value limit.
+func childLabels(page *v1alpha1.FrontendPage) map[string]string {
+	instance := page.Name
+	if len(validation.IsValidLabelValue(instance)) != 0 {
+		instance = truncateWithHash(instance, page.Name, validation.LabelValueMaxLength)
+	}
+	return map[string]string{
+		"app.kubernetes.io/name":       "frontendpage",
+		"app.kubernetes.io/instance":   instance,
+		"app.kubernetes.io/managed-by": "go-kubernetes-controllers",
+	}
+}
+
+// truncateWithHash cuts name so that, with a short hash of key appended, it fits
+// maxLength. Trailing dashes and dots are dropped so the result stays a valid
+// name or label value.
+func truncateWithHash(name, key string, maxLength int) string {
+	sum := sha256.Sum256([]byte(key))
+	suffix := "-" + hex.EncodeToString(sum[:])[:8]
+	if len(name) > maxLength-len(suffix) {
+		name = name[:maxLength-len(suffix)]
+	}
+	return strings.TrimRight(name, "-.") + suffix
+}
+
+// serviceURL returns the in-cluster address of the Service exposing a FrontendPage
+func serviceURL(svc *corev1.Service) string {
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local", svc.Name, svc.Namespace)
+}
+
+// contentHash returns a short, stable hash of the rendered page content
+func contentHash(html string) string {
+	sum := sha256.Sum256([]byte(html))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// mutateConfigMap sets the desired state of the ConfigMap holding the rendered page
+func mutateConfigMap(cm *corev1.ConfigMap, page *v1alpha1.FrontendPage, html string) {
+	cm.Labels = mergeLabels(cm.Labels, childLabels(page))
+	cm.Data = map[string]string{pageIndexKey: html}
+}
+
+// mutateDeployment sets the desired state of the Deployment serving the rendered page.
+// Fields are set individually so that values defaulted by the API server are preserved
+// and repeated reconciles do not produce spurious updates.
+func mutateDeployment(dep *appsv1.Deployment, page *v1alpha1.FrontendPage, configMapName, hash, image string) {
+	labels := childLabels(page)
+	dep.Labels = mergeLabels(dep.Labels, labels)
+
+	if dep.Spec.Replicas == nil {
+		replicas := int32(1)
+		dep.Spec.Replicas = &replicas
+	}
+	// The selector is immutable, so only set it on creation
+	if dep.CreationTimestamp.IsZero() {
+		dep.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+	}
+
+	tmpl := &dep.Spec.Template
+	tmpl.Labels = mergeLabels(tmpl.Labels, labels)
+	if tmpl.Annotations == nil {
+		tmpl.Annotations = map[string]string{}
+	}
+	tmpl.Annotations[configHashAnnotation] = hash
+
+	var volume *corev1.Volume
+	for i := range tmpl.Spec.Volumes {
+		if tmpl.Spec.Volumes[i].Name == pageContentVolumeName {
+			volume = &tmpl.Spec.Volumes[i]
+		}
+	}
+	if volume == nil {
+		tmpl.Spec.Volumes = append(tmpl.Spec.Volumes, corev1.Volume{Name: pageContentVolumeName})
+		volume = &tmpl.Spec.Volumes[len(tmpl.Spec.Volumes)-1]
+	}
+	defaultMode := corev1.ConfigMapVolumeSourceDefaultMode
+	volume.VolumeSource = corev1.VolumeSource{
+		ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+			DefaultMode:          &defaultMode,
+		},
+	}
+
+	var container *corev1.Container
+	for i := range tmpl.Spec.Containers {
+		if tmpl.Spec.Containers[i].Name == pageServerContainerName {
+			container = &tmpl.Spec.Containers[i]
+		}
+	}
+	if container == nil {
+		tmpl.Spec.Containers = append(tmpl.Spec.Containers, corev1.Container{Name: pageServerContainerName})
+		container = &tmpl.Spec.Containers[len(tmpl.Spec.Containers)-1]
+	}
+	container.Image = image
+	container.Ports = []corev1.ContainerPort{{
+		Name:          "http",
+		ContainerPort: pageServerPort,
+		Protocol:      corev1.ProtocolTCP,
+	}}
+	container.VolumeMounts = []corev1.VolumeMount{{
+		Name:      pageContentVolumeName,
+		MountPath: pageContentMountPath,
+		ReadOnly:  true,
+	}}
+	// Bounded resources keep the page server compliant with the Deployment policy
+	container.Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+			corev1.ResourceMemory: resource.MustParse("16Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+	}
+}
+
+// mutateService sets the desired state of the Service exposing the page server
+func mutateService(svc *corev1.Service, page *v1alpha1.FrontendPage) {
+	labels := childLabels(page)
+	svc.Labels = mergeLabels(svc.Labels, labels)
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	svc.Spec.Selector = labels
+	svc.Spec.Ports = []corev1.ServicePort{{
+		Name:       "http",
+		Port:       pageServerPort,
+		TargetPort: intstr.FromString("http"),
+		Protocol:   corev1.ProtocolTCP,
+	}}
+}
+
+// deploymentAvailable reports whether the Deployment has observed its latest
+// spec and reports the Available condition
+func deploymentAvailable(dep *appsv1.Deployment) bool {
+	if dep.Status.ObservedGeneration < dep.Generation {
+		return false
+	}
+	cond := deploymentCondition(dep, appsv1.DeploymentAvailable)
+	return cond != nil && cond.Status == corev1.ConditionTrue
+}
+
+// deploymentRolledOut reports whether all replicas of the Deployment run the latest pod template
+func deploymentRolledOut(dep *appsv1.Deployment) bool {
+	if dep.Status.ObservedGeneration < dep.Generation {
+		return false
+	}
+	replicas := int32(1)
+	if dep.Spec.Replicas != nil {
+		replicas = *dep.Spec.Replicas
+	}
+	return dep.Status.UpdatedReplicas == replicas &&
+		dep.Status.Replicas == replicas &&
+		dep.Status.AvailableReplicas == replicas
+}
+
+// deploymentCondition returns the Deployment condition with the given type, if any
+func deploymentCondition(dep *appsv1.Deployment, conditionType appsv1.DeploymentConditionType) *appsv1.DeploymentCondition {
+	for i := range dep.Status.Conditions {
+		if dep.Status.Conditions[i].Type == conditionType {
+			return &dep.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func mergeLabels(existing, desired map[string]string) map[string]string {
+	if existing == nil {
+		existing = make(map[string]string, len(desired))
+	}
+	for k, v := range desired {
+		existing[k] = v
+	}
+	return existing
+}
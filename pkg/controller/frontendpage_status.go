@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+)
+
+// Reasons used for FrontendPage conditions
+const (
+	ReasonAvailable                = "Available"
+	ReasonDeploymentUnavailable    = "DeploymentUnavailable"
+	ReasonInvalidSpec              = "InvalidSpec"
+	ReasonRenderSucceeded          = "RenderSucceeded"
+	ReasonRenderFailed             = "RenderFailed"
+	ReasonReconcileFailed          = "ReconcileFailed"
+	ReasonRollingOut               = "RollingOut"
+	ReasonRolloutComplete          = "RolloutComplete"
+	ReasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+	ReasonAsExpected               = "AsExpected"
+)
+
+// setCondition sets a condition observed at the page's current generation.
+// LastTransitionTime only changes when the condition status changes.
+func setCondition(page *v1alpha1.FrontendPage, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&page.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: page.Generation,
+	})
+}
+
+// setInvalidSpecConditions reports a spec that failed validation
+func setInvalidSpecConditions(page *v1alpha1.FrontendPage, message string) {
+	setCondition(page, v1alpha1.ConditionRendered, metav1.ConditionFalse, ReasonInvalidSpec, message)
+	setCondition(page, v1alpha1.ConditionReady, metav1.ConditionFalse, ReasonInvalidSpec, message)
+	setCondition(page, v1alpha1.ConditionProgressing, metav1.ConditionFalse, ReasonInvalidSpec, "Rollout is blocked until the spec is fixed")
+	setCondition(page, v1alpha1.ConditionDegraded, metav1.ConditionTrue, ReasonInvalidSpec, message)
+}
+
+// setRenderFailedConditions reports a valid spec that could not be rendered
+func setRenderFailedConditions(page *v1alpha1.FrontendPage, err error) {
+	setCondition(page, v1alpha1.ConditionRendered, metav1.ConditionFalse, ReasonRenderFailed, err.Error())
+	setCondition(page, v1alpha1.ConditionReady, metav1.ConditionFalse, ReasonRenderFailed, err.Error())
+	setCondition(page, v1alpha1.ConditionDegraded, metav1.ConditionTrue, ReasonRenderFailed, err.Error())
+}
+
+// setRenderedCondition reports a successfully rendered spec
+func setRenderedCondition(page *v1alpha1.FrontendPage) {
+	setCondition(page, v1alpha1.ConditionRendered, metav1.ConditionTrue, ReasonRenderSucceeded,
+		fmt.Sprintf("Rendered %d component(s)", len(page.Spec.Components)))
+}
+
+// setReconcileFailedConditions reports a failure to create or update the owned children
+func setReconcileFailedConditions(page *v1alpha1.FrontendPage, err error) {
+	setCondition(page, v1alpha1.ConditionReady, metav1.ConditionFalse, ReasonReconcileFailed, err.Error())
+	setCondition(page, v1alpha1.ConditionDegraded, metav1.ConditionTrue, ReasonReconcileFailed, err.Error())
+}
+
+// setDeploymentConditions derives Ready, Progressing and Degraded from the child Deployment
+func setDeploymentConditions(page *v1alpha1.FrontendPage, dep *appsv1.Deployment, url string) {
+	if deploymentAvailable(dep) {
+		setCondition(page, v1alpha1.ConditionReady, metav1.ConditionTrue, ReasonAvailable,
+			fmt.Sprintf("Page is served at %s", url))
+	} else {
+		setCondition(page, v1alpha1.ConditionReady, metav1.ConditionFalse, ReasonDeploymentUnavailable,
+			fmt.Sprintf("Waiting for deployment %s to become available", dep.Name))
+	}
+
+	if deploymentRolledOut(dep) {
+		setCondition(page, v1alpha1.ConditionProgressing, metav1.ConditionFalse, ReasonRolloutComplete,
+			fmt.Sprintf("Deployment %s is rolled out", dep.Name))
+	} else {
+		setCondition(page, v1alpha1.ConditionProgressing, metav1.ConditionTrue, ReasonRollingOut,
+			fmt.Sprintf("Deployment %s is rolling out", dep.Name))
+	}
+
+	if cond := deploymentCondition(dep, appsv1.DeploymentProgressing); cond != nil &&
+		cond.Status == corev1.ConditionFalse && cond.Reason == "ProgressDeadlineExceeded" {
+		setCondition(page, v1alpha1.ConditionDegraded, metav1.ConditionTrue, ReasonProgressDeadlineExceeded, cond.Message)
+	} else {
+		setCondition(page, v1alpha1.ConditionDegraded, metav1.ConditionFalse, ReasonAsExpected, "")
+	}
+}
+
+// derivePhase summarizes the conditions into the legacy Phase field. Invalid
+// is reserved for specs that failed validation; a valid spec that could not be
+// rendered is Failed.
+func derivePhase(conditions []metav1.Condition) string {
+	rendered := meta.FindStatusCondition(conditions, v1alpha1.ConditionRendered)
+	switch {
+	case meta.IsStatusConditionTrue(conditions, v1alpha1.ConditionReady):
+		return v1alpha1.PhaseReady
+	case rendered != nil && rendered.Status == metav1.ConditionFalse && rendered.Reason == ReasonInvalidSpec:
+		return v1alpha1.PhaseInvalid
+	case rendered != nil && rendered.Status == metav1.ConditionFalse:
+		return v1alpha1.PhaseFailed
+	case meta.IsStatusConditionTrue(conditions, v1alpha1.ConditionDegraded):
+		return v1alpha1.PhaseDegraded
+	default:
+		return v1alpha1.PhasePending
+	}
+}
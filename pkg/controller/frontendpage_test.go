@@ -0,0 +1,270 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+)
+
+func newTestFrontendPage() *v1alpha1.FrontendPage {
+	return &v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "default", UID: "page-uid"},
+		Spec: v1alpha1.FrontendPageSpec{
+			Title:    "Dashboard",
+			Template: "dashboard",
+			Theme:    "light",
+			Components: []v1alpha1.Component{
+				{Name: "metrics", Type: "table", Config: map[string]interface{}{"columns": []interface{}{"Name", "Value"}}},
+			},
+		},
+	}
+}
+
+func TestFrontendPageReconcileCreatesOwnedChildren(t *testing.T) {
+	scheme := k8s.NewScheme()
+	page := newTestFrontendPage()
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(page).
+		WithStatusSubresource(&v1alpha1.FrontendPage{}).
+		Build()
+	r := &FrontendPageReconciler{Client: c, Scheme: scheme}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(page)}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+
+	childKey := types.NamespacedName{Namespace: "default", Name: "frontendpage-dashboard"}
+	cm := &corev1.ConfigMap{}
+	dep := &appsv1.Deployment{}
+	svc := &corev1.Service{}
+	for _, obj := range []client.Object{cm, dep, svc} {
+		if err := c.Get(ctx, childKey, obj); err != nil {
+			t.Fatalf("expected child %T to exist: %v", obj, err)
+		}
+		owner := metav1.GetControllerOf(obj)
+		if owner == nil || owner.UID != page.UID || owner.Kind != "FrontendPage" {
+			t.Errorf("expected %T to be controlled by the FrontendPage, got %v", obj, owner)
+		}
+	}
+	if cm.Data[pageIndexKey] == "" {
+		t.Errorf("expected rendered page in configmap")
+	}
+	if dep.Spec.Template.Annotations[configHashAnnotation] != contentHash(cm.Data[pageIndexKey]) {
+		t.Errorf("expected deployment to carry the content hash of the configmap")
+	}
+	if violations := DefaultDeploymentPolicy().Check(dep); len(violations) != 0 {
+		t.Errorf("expected the page server deployment to comply with the default policy, got %v", violations)
+	}
+
+	got := &v1alpha1.FrontendPage{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("failed to get FrontendPage: %v", err)
+	}
+	if got.Status.Phase != "Pending" {
+		t.Errorf("expected phase Pending before the deployment is available, got %q", got.Status.Phase)
+	}
+	if got.Status.URL != "http://frontendpage-dashboard.default.svc.cluster.local" {
+		t.Errorf("unexpected URL %q", got.Status.URL)
+	}
+	if got.Status.ObservedGeneration != got.Generation {
+		t.Errorf("expected observedGeneration %d, got %d", got.Generation, got.Status.ObservedGeneration)
+	}
+	for condType, want := range map[string]metav1.ConditionStatus{
+		v1alpha1.ConditionReady:       metav1.ConditionFalse,
+		v1alpha1.ConditionRendered:    metav1.ConditionTrue,
+		v1alpha1.ConditionProgressing: metav1.ConditionTrue,
+		v1alpha1.ConditionDegraded:    metav1.ConditionFalse,
+	} {
+		cond := meta.FindStatusCondition(got.Status.Conditions, condType)
+		if cond == nil || cond.Status != want {
+			t.Errorf("expected condition %s to be %s, got %+v", condType, want, cond)
+		}
+	}
+
+	// Mark the child deployment available and reconcile again
+	dep.Status.ObservedGeneration = dep.Generation
+	dep.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue}}
+	if err := c.Status().Update(ctx, dep); err != nil {
+		t.Fatalf("failed to update deployment status: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("failed to get FrontendPage: %v", err)
+	}
+	if got.Status.Phase != "Ready" {
+		t.Errorf("expected phase Ready once the deployment is available, got %q", got.Status.Phase)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, v1alpha1.ConditionReady) {
+		t.Errorf("expected Ready condition to be True, got %+v", got.Status.Conditions)
+	}
+}
+
+func TestFrontendPageReconcileRejectsInvalidComponents(t *testing.T) {
+	scheme := k8s.NewScheme()
+	page := newTestFrontendPage()
+	page.Spec.Components = append(page.Spec.Components,
+		v1alpha1.Component{Name: "broken", Type: "table", Config: map[string]interface{}{"colums": []interface{}{"Name"}}},
+		v1alpha1.Component{Name: "mystery", Type: "carousel"},
+	)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(page).
+		WithStatusSubresource(&v1alpha1.FrontendPage{}).
+		Build()
+	r := &FrontendPageReconciler{Client: c, Scheme: scheme}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(page)}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+
+	got := &v1alpha1.FrontendPage{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("failed to get FrontendPage: %v", err)
+	}
+	if got.Status.Phase != "Invalid" {
+		t.Errorf("expected phase Invalid, got %q", got.Status.Phase)
+	}
+	rendered := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.ConditionRendered)
+	if rendered == nil || rendered.Status != metav1.ConditionFalse || rendered.Reason != ReasonInvalidSpec {
+		t.Errorf("expected Rendered=False with reason %s, got %+v", ReasonInvalidSpec, rendered)
+	}
+	for _, want := range []string{"spec.components[1].config.colums", `spec.components[2].type: Unsupported value: "carousel"`} {
+		if !strings.Contains(got.Status.Message, want) {
+			t.Errorf("expected status message to contain %q, got %q", want, got.Status.Message)
+		}
+	}
+
+	dep := &appsv1.Deployment{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "frontendpage-dashboard"}, dep)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected no deployment for an invalid page, got %v", err)
+	}
+}
+
+func TestFrontendPageReconcileSkipsUnchangedStatus(t *testing.T) {
+	scheme := k8s.NewScheme()
+	page := newTestFrontendPage()
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(page).
+		WithStatusSubresource(&v1alpha1.FrontendPage{}).
+		Build()
+	r := &FrontendPageReconciler{Client: c, Scheme: scheme}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(page)}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	first := &v1alpha1.FrontendPage{}
+	if err := c.Get(ctx, req.NamespacedName, first); err != nil {
+		t.Fatalf("failed to get FrontendPage: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatalf("reconcile failed: %v", err)
+		}
+	}
+	got := &v1alpha1.FrontendPage{}
+	if err := c.Get(ctx, req.NamespacedName, got); err != nil {
+		t.Fatalf("failed to get FrontendPage: %v", err)
+	}
+	if got.ResourceVersion != first.ResourceVersion {
+		t.Errorf("expected no writes for an unchanged status, resourceVersion went from %s to %s",
+			first.ResourceVersion, got.ResourceVersion)
+	}
+	if !got.Status.LastUpdated.Equal(first.Status.LastUpdated) {
+		t.Errorf("expected LastUpdated to be unchanged")
+	}
+}
+
+func TestDerivePhase(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions func(page *v1alpha1.FrontendPage)
+		want       string
+	}{
+		{name: "no conditions", conditions: func(*v1alpha1.FrontendPage) {}, want: v1alpha1.PhasePending},
+		{name: "invalid spec", conditions: func(page *v1alpha1.FrontendPage) {
+			setInvalidSpecConditions(page, "unknown component type")
+		}, want: v1alpha1.PhaseInvalid},
+		{name: "render failed", conditions: func(page *v1alpha1.FrontendPage) {
+			setRenderFailedConditions(page, errors.New("template not found"))
+		}, want: v1alpha1.PhaseFailed},
+		{name: "reconcile failed", conditions: func(page *v1alpha1.FrontendPage) {
+			setRenderedCondition(page)
+			setReconcileFailedConditions(page, errors.New("conflict"))
+		}, want: v1alpha1.PhaseDegraded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := newTestFrontendPage()
+			tt.conditions(page)
+			if got := derivePhase(page.Status.Conditions); got != tt.want {
+				t.Errorf("expected phase %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestChildNamesAreValidForLongPageNames(t *testing.T) {
+	long := strings.Repeat("a", 60)
+	tests := []struct {
+		name     string
+		pageName string
+		want     string
+	}{
+		{name: "short name", pageName: "dashboard", want: "frontendpage-dashboard"},
+		{name: "long name", pageName: long},
+		{name: "long name with another suffix", pageName: long + "b"},
+		{name: "dotted name", pageName: "shop.example.com"},
+		{name: "dashed name", pageName: "shop-example-com"},
+	}
+	seen := map[string]string{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := newTestFrontendPage()
+			page.Name = tt.pageName
+			name := childName(page)
+			if tt.want != "" && name != tt.want {
+				t.Errorf("expected child name %q, got %q", tt.want, name)
+			}
+			if errs := validation.IsDNS1035Label(name); len(errs) > 0 {
+				t.Errorf("child name %q is not a valid Service name: %v", name, errs)
+			}
+			if instance := childLabels(page)["app.kubernetes.io/instance"]; len(validation.IsValidLabelValue(instance)) > 0 {
+				t.Errorf("instance label %q is not a valid label value", instance)
+			}
+			if other, ok := seen[name]; ok {
+				t.Errorf("pages %q and %q share the child name %q", other, tt.pageName, name)
+			}
+			seen[name] = tt.pageName
+			if childName(page) != name {
+				t.Errorf("expected child name to be stable")
+			}
+		})
+	}
+}
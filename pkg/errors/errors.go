@@ -39,6 +39,12 @@ type (
 		Message string
 		Err     error
 	}
+
+	// RolloutError represents rollouts that failed or did not finish in time
+	RolloutError struct {
+		Message string
+		Err     error
+	}
 )
 
 // Error implementations
@@ -81,6 +87,22 @@ func (e *ResyncError) Error() string {
 	return fmt.Sprintf("resync error: %s", e.Message)
 }
 
+func (e *RolloutError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("rollout error: %s: %v", e.Message, e.Err)
+	}
+	return fmt.Sprintf("rollout error: %s", e.Message)
+}
+
+// Unwrap implementations, so that errors.Is and errors.As and checks such as
+// apierrors.IsNotFound see the underlying error
+func (e *ConfigError) Unwrap() error     { return e.Err }
+func (e *ConnectionError) Unwrap() error { return e.Err }
+func (e *WatchError) Unwrap() error      { return e.Err }
+func (e *CacheError) Unwrap() error      { return e.Err }
+func (e *ResyncError) Unwrap() error     { return e.Err }
+func (e *RolloutError) Unwrap() error    { return e.Err }
+
 // Helper functions to create errors
 func NewConfigError(message string, err error) *ConfigError {
 	return &ConfigError{Message: message, Err: err}
@@ -105,3 +127,7 @@ func NewCacheError(message string, err error) *CacheError {
 func NewResyncError(message string, err error) *ResyncError {
 	return &ResyncError{Message: message, Err: err}
 }
+
+func NewRolloutError(message string, err error) *RolloutError {
+	return &RolloutError{Message: message, Err: err}
+}
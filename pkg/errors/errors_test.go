@@ -1,6 +1,8 @@
 package errors
 
 import (
+	stderrors "errors"
+	"io"
 	"testing"
 )
 
@@ -24,3 +26,20 @@ func TestNewValidationError(t *testing.T) {
 		t.Errorf("expected 'validation error for field 'field': test message', got '%s'", err.Error())
 	}
 }
+
+func TestNewRolloutError(t *testing.T) {
+	err := NewRolloutError("test message", nil)
+	if err.Error() != "rollout error: test message" {
+		t.Errorf("expected 'rollout error: test message', got '%s'", err.Error())
+	}
+}
+
+func TestErrorsUnwrap(t *testing.T) {
+	err := NewConnectionError("failed to get page", io.ErrUnexpectedEOF)
+	if !stderrors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected the connection error to wrap %v", io.ErrUnexpectedEOF)
+	}
+	if stderrors.Unwrap(NewConfigError("test message", nil)) != nil {
+		t.Errorf("expected nothing to unwrap without a cause")
+	}
+}
@@ -0,0 +1,97 @@
+package informer
+
+import "sync"
+
+// cacheBudget counts the objects cached by one or more informers against a
+// shared MaxCacheSize. An Informer has a budget of its own; the informers of
+// all namespaces of a MultiNamespaceInformer share one.
+type cacheBudget struct {
+	limit int
+
+	mu    sync.Mutex
+	total int64
+	over  bool
+	// sizes counts the objects charged by each informer; informers that are
+	// not in it are no longer charged
+	sizes map[*Informer]int64
+}
+
+func newCacheBudget(limit int) *cacheBudget {
+	return &cacheBudget{limit: limit, sizes: make(map[*Informer]int64)}
+}
+
+// attach starts charging the objects of inf to the budget
+func (b *cacheBudget) attach(inf *Informer) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.sizes[inf]; !ok {
+		b.sizes[inf] = 0
+	}
+}
+
+// add charges delta objects of inf and returns the new total. crossed is set
+// when the total went over the limit or back under it, with over telling which.
+func (b *cacheBudget) add(inf *Informer, delta int64) (total int64, over, crossed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.sizes[inf]; !ok {
+		return b.total, b.over, false
+	}
+	b.sizes[inf] += delta
+	b.total += delta
+	return b.total, b.over, b.update()
+}
+
+// release stops charging the objects of inf, whose cache is dropped, and
+// reports whether this brought the total back under the limit
+func (b *cacheBudget) release(inf *Informer) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	size, ok := b.sizes[inf]
+	if !ok {
+		return false
+	}
+	delete(b.sizes, inf)
+	b.total -= size
+	return b.update() && !b.over
+}
+
+// update recomputes over and reports whether it changed
+func (b *cacheBudget) update() bool {
+	over := b.total > int64(b.limit)
+	changed := over != b.over
+	b.over = over
+	return changed
+}
+
+// exceeded reports whether more than limit objects are cached
+func (b *cacheBudget) exceeded() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.over
+}
+
+// exceededWith reports whether the total would be over the limit if inf held
+// size objects
+func (b *cacheBudget) exceededWith(inf *Informer, size int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.total-b.sizes[inf]+int64(size) > int64(b.limit)
+}
+
+// remaining returns the number of objects that can still be cached
+func (b *cacheBudget) remaining() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if remaining := int64(b.limit) - b.total; remaining > 0 {
+		return int(remaining)
+	}
+	return 0
+}
+
+// shareBudget charges the cached objects of i to budget instead of its own
+// budget. It must be called before Start.
+func (i *Informer) shareBudget(budget *cacheBudget) {
+	budget.attach(i)
+	i.budget = budget
+}
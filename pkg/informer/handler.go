@@ -0,0 +1,214 @@
+package informer
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+)
+
+// EventHandler processes informer events. Returning an error requeues the event
+// with backoff, so handlers must be idempotent: an event may be delivered again
+// after a failure, also to handlers that already succeeded for it.
+type EventHandler interface {
+	Handle(ctx context.Context, event types.Event) error
+}
+
+// EventHandlerFunc adapts a function to an EventHandler
+type EventHandlerFunc func(ctx context.Context, event types.Event) error
+
+// Handle calls f(ctx, event)
+func (f EventHandlerFunc) Handle(ctx context.Context, event types.Event) error {
+	return f(ctx, event)
+}
+
+// Filter decides whether an event is passed on to a handler
+type Filter func(event types.Event) bool
+
+// Middleware wraps an EventHandler with cross-cutting behaviour
+type Middleware func(next EventHandler) EventHandler
+
+// Filtered returns a handler that passes only events accepted by all filters
+// to handler. Other events are skipped and count as handled.
+func Filtered(handler EventHandler, filters ...Filter) EventHandler {
+	return EventHandlerFunc(func(ctx context.Context, event types.Event) error {
+		for _, filter := range filters {
+			if !filter(event) {
+				return nil
+			}
+		}
+		return handler.Handle(ctx, event)
+	})
+}
+
+// Chain wraps handler in middleware. The first middleware is the outermost, so
+// Chain(h, RecoveryMiddleware(l), TimeoutMiddleware(d)) recovers panics of the
+// handler including those raised after its timeout.
+func Chain(handler EventHandler, middleware ...Middleware) EventHandler {
+	for j := len(middleware) - 1; j >= 0; j-- {
+		handler = middleware[j](handler)
+	}
+	return handler
+}
+
+// NamespaceFilter accepts events for objects in one of the given namespaces
+func NamespaceFilter(namespaces ...string) Filter {
+	allowed := sets.New(namespaces...)
+	return func(event types.Event) bool {
+		return allowed.Has(event.Namespace)
+	}
+}
+
+// EventTypeFilter accepts events of the given types ("add", "update", "delete")
+func EventTypeFilter(eventTypes ...string) Filter {
+	allowed := sets.New(eventTypes...)
+	return func(event types.Event) bool {
+		return allowed.Has(event.Type)
+	}
+}
+
+// LabelSelectorFilter accepts events for objects whose labels match selector
+func LabelSelectorFilter(selector labels.Selector) Filter {
+	return func(event types.Event) bool {
+		object, err := meta.Accessor(event.Object)
+		if err != nil {
+			return false
+		}
+		return selector.Matches(labels.Set(object.GetLabels()))
+	}
+}
+
+// LogHandler returns a handler that logs every event
+func LogHandler(logger zerolog.Logger) EventHandler {
+	return EventHandlerFunc(func(_ context.Context, event types.Event) error {
+		logger.Info().
+			Str("type", event.Type).
+			Str("resource", event.Resource).
+			Str("namespace", event.Namespace).
+			Str("name", event.Name).
+			Time("timestamp", event.Timestamp).
+			Bool("finalStateUnknown", event.FinalStateUnknown).
+			Msg("processing event")
+		return nil
+	})
+}
+
+// LoggingMiddleware logs the outcome and duration of every handled event
+func LoggingMiddleware(logger zerolog.Logger) Middleware {
+	return func(next EventHandler) EventHandler {
+		return EventHandlerFunc(func(ctx context.Context, event types.Event) error {
+			start := time.Now()
+			err := next.Handle(ctx, event)
+			entry := logger.Debug()
+			if err != nil {
+				entry = logger.Warn().Err(err)
+			}
+			entry.
+				Str("type", event.Type).
+				Str("resource", event.Resource).
+				Str("namespace", event.Namespace).
+				Str("name", event.Name).
+				Dur("duration", time.Since(start)).
+				Msg("event handled")
+			return err
+		})
+	}
+}
+
+// RecoveryMiddleware turns a panicking handler into an error, so the event is
+// retried instead of crashing the worker
+func RecoveryMiddleware(logger zerolog.Logger) Middleware {
+	return func(next EventHandler) EventHandler {
+		return EventHandlerFunc(func(ctx context.Context, event types.Event) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.Error().
+						Str("namespace", event.Namespace).
+						Str("name", event.Name).
+						Str("stack", string(debug.Stack())).
+						Msgf("event handler panicked: %v", r)
+					err = fmt.Errorf("event handler panicked: %v", r)
+				}
+			}()
+			return next.Handle(ctx, event)
+		})
+	}
+}
+
+// TimeoutMiddleware bounds every handler call by timeout. Handlers must honour
+// the context; an expired context is reported as an error and retried.
+func TimeoutMiddleware(timeout time.Duration) Middleware {
+	return func(next EventHandler) EventHandler {
+		return EventHandlerFunc(func(ctx context.Context, event types.Event) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			if err := next.Handle(ctx, event); err != nil {
+				return err
+			}
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("event handler exceeded timeout of %s: %w", timeout, ctx.Err())
+			}
+			return nil
+		})
+	}
+}
+
+// handlerMetrics are the collectors recorded by MetricsMiddleware
+type handlerMetrics struct {
+	events   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// MetricsMiddleware records the number of handled events by result and the
+// handler latency in registerer. It can be used for several handlers of the
+// same registerer; the collectors are registered once.
+func MetricsMiddleware(registerer prometheus.Registerer) Middleware {
+	m := &handlerMetrics{
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "informer_handled_events_total",
+			Help: "Number of informer events handled, by resource, event type and result.",
+		}, []string{"resource", "type", "result"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "informer_handler_duration_seconds",
+			Help:    "Time spent handling informer events, by resource and event type.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"resource", "type"}),
+	}
+	m.events = registerOrReuse(registerer, m.events)
+	m.duration = registerOrReuse(registerer, m.duration)
+
+	return func(next EventHandler) EventHandler {
+		return EventHandlerFunc(func(ctx context.Context, event types.Event) error {
+			start := time.Now()
+			err := next.Handle(ctx, event)
+			result := "success"
+			if err != nil {
+				result = "error"
+			}
+			m.events.WithLabelValues(event.Resource, event.Type, result).Inc()
+			m.duration.WithLabelValues(event.Resource, event.Type).Observe(time.Since(start).Seconds())
+			return err
+		})
+	}
+}
+
+// registerOrReuse registers collector, returning the already registered
+// collector if an identical one exists
+func registerOrReuse[C prometheus.Collector](registerer prometheus.Registerer, collector C) C {
+	if err := registerer.Register(collector); err != nil {
+		if registered, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			if existing, ok := registered.ExistingCollector.(C); ok {
+				return existing
+			}
+		}
+	}
+	return collector
+}
@@ -0,0 +1,183 @@
+package informer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+)
+
+func newTestEvent(eventType, namespace string, objectLabels map[string]string) types.Event {
+	return types.Event{
+		Type:      eventType,
+		Resource:  "deployments.apps",
+		Namespace: namespace,
+		Name:      "web",
+		Object: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name: "web", Namespace: namespace, Labels: objectLabels,
+		}},
+	}
+}
+
+func TestFilteredHandler(t *testing.T) {
+	var handled []string
+	handler := Filtered(
+		EventHandlerFunc(func(_ context.Context, event types.Event) error {
+			handled = append(handled, event.Type+" "+event.Namespace)
+			return nil
+		}),
+		NamespaceFilter("prod", "staging"),
+		EventTypeFilter("add", "delete"),
+		LabelSelectorFilter(labels.SelectorFromSet(labels.Set{"team": "payments"})),
+	)
+
+	events := []types.Event{
+		newTestEvent("add", "prod", map[string]string{"team": "payments"}),
+		newTestEvent("add", "dev", map[string]string{"team": "payments"}),
+		newTestEvent("update", "prod", map[string]string{"team": "payments"}),
+		newTestEvent("delete", "staging", map[string]string{"team": "payments", "tier": "web"}),
+		newTestEvent("delete", "staging", map[string]string{"team": "search"}),
+	}
+	for _, event := range events {
+		if err := handler.Handle(context.Background(), event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	want := []string{"add prod", "delete staging"}
+	if strings.Join(handled, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v to be handled, got %v", want, handled)
+	}
+}
+
+func TestChainOrdersMiddleware(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next EventHandler) EventHandler {
+			return EventHandlerFunc(func(ctx context.Context, event types.Event) error {
+				calls = append(calls, name+":before")
+				err := next.Handle(ctx, event)
+				calls = append(calls, name+":after")
+				return err
+			})
+		}
+	}
+	handler := Chain(EventHandlerFunc(func(context.Context, types.Event) error {
+		calls = append(calls, "handler")
+		return nil
+	}), trace("outer"), trace("inner"))
+
+	if err := handler.Handle(context.Background(), types.Event{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "outer:before,inner:before,handler,inner:after,outer:after"
+	if got := strings.Join(calls, ","); got != want {
+		t.Errorf("expected calls %s, got %s", want, got)
+	}
+}
+
+func TestRecoveryMiddlewareReturnsPanicsAsErrors(t *testing.T) {
+	handler := Chain(EventHandlerFunc(func(context.Context, types.Event) error {
+		panic("boom")
+	}), RecoveryMiddleware(zerolog.Nop()))
+
+	err := handler.Handle(context.Background(), types.Event{})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected the panic as an error, got %v", err)
+	}
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	slow := EventHandlerFunc(func(ctx context.Context, _ types.Event) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	})
+	if err := Chain(slow, TimeoutMiddleware(10*time.Millisecond)).Handle(context.Background(), types.Event{}); err == nil {
+		t.Errorf("expected a slow handler to time out")
+	}
+
+	fast := EventHandlerFunc(func(context.Context, types.Event) error { return nil })
+	if err := Chain(fast, TimeoutMiddleware(time.Second)).Handle(context.Background(), types.Event{}); err != nil {
+		t.Errorf("expected a fast handler to succeed, got %v", err)
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	failing := true
+	handler := EventHandlerFunc(func(context.Context, types.Event) error {
+		if failing {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+	// Both chains share the collectors of the registry
+	first := Chain(handler, MetricsMiddleware(registry))
+	second := Chain(handler, MetricsMiddleware(registry))
+
+	event := newTestEvent("add", "prod", nil)
+	_ = first.Handle(context.Background(), event)
+	failing = false
+	_ = second.Handle(context.Background(), event)
+	_ = second.Handle(context.Background(), event)
+
+	expected := `
+# HELP informer_handled_events_total Number of informer events handled, by resource, event type and result.
+# TYPE informer_handled_events_total counter
+informer_handled_events_total{resource="deployments.apps",result="error",type="add"} 1
+informer_handled_events_total{resource="deployments.apps",result="success",type="add"} 2
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "informer_handled_events_total"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInformerRetriesEventsOfFailingHandlers(t *testing.T) {
+	inf, err := NewInformer(kubefake.NewSimpleClientset(), newTestConfig())
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	if err := inf.indexer.Add(dep); err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+
+	var audited, flaky int
+	inf.AddEventHandler(EventHandlerFunc(func(context.Context, types.Event) error {
+		audited++
+		return nil
+	}))
+	inf.AddEventHandler(Chain(EventHandlerFunc(func(context.Context, types.Event) error {
+		flaky++
+		if flaky == 1 {
+			panic("audit sink crashed")
+		}
+		return nil
+	}), RecoveryMiddleware(zerolog.Nop())))
+
+	inf.handleAdd(dep)
+	processNextKey(t, inf)
+	processNextKey(t, inf)
+
+	if flaky != 2 || audited != 2 {
+		t.Errorf("expected both handlers to see the retried event, got %d and %d calls", audited, flaky)
+	}
+	if retried := inf.Health().RetriedEvents; retried != 1 {
+		t.Errorf("expected one retry, got %d", retried)
+	}
+}
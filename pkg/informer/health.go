@@ -0,0 +1,129 @@
+package informer
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+)
+
+// watchErrorWindow is how long a watch error keeps the informer unhealthy when
+// nothing was heard from the API server since. The reflector retries a failed
+// list or watch with a backoff of at most 30s, so a watch that keeps failing
+// reports a new error well within the window.
+const watchErrorWindow = time.Minute
+
+// Health returns the informer health status
+func (i *Informer) Health() types.InformerHealth {
+	i.mu.RLock()
+	health := *i.health
+	i.mu.RUnlock()
+
+	health.CacheSize = len(i.indexer.ListKeys())
+	health.WorkersAlive = int(i.workersAlive.Load())
+	health.QueueLength = i.queue.Len()
+	health.DroppedEvents = i.stats.Dropped.Load()
+	health.RetriedEvents = i.stats.Retried.Load()
+	health.CoalescedEvents = i.stats.Coalesced.Load()
+
+	watchFailing := health.LastErrorTime.After(health.LastSync) &&
+		time.Since(health.LastErrorTime) < watchErrorWindow
+	health.IsHealthy = health.WorkersAlive == health.Workers && !watchFailing
+	health.IsReady = health.IsHealthy && i.informer.HasSynced()
+	return health
+}
+
+// HealthzHandler serves Health as JSON with status 200 while the informer is
+// healthy and 503 otherwise
+func (i *Informer) HealthzHandler() http.Handler {
+	return healthHandler(i.Health, func(health types.InformerHealth) bool { return health.IsHealthy }, i.logger)
+}
+
+// ReadyzHandler serves Health as JSON with status 200 once the informer is
+// ready and 503 otherwise
+func (i *Informer) ReadyzHandler() http.Handler {
+	return healthHandler(i.Health, func(health types.InformerHealth) bool { return health.IsReady }, i.logger)
+}
+
+// healthHandler serves the health returned by getHealth, with status 503 unless ok
+func healthHandler(getHealth func() types.InformerHealth, ok func(types.InformerHealth) bool, logger zerolog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		health := getHealth()
+		status := http.StatusOK
+		if !ok(health) {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(health); err != nil {
+			logger.Error().Err(err).Msg("failed to write health response")
+		}
+	})
+}
+
+// handleWatchError records list and watch failures reported by the reflector
+func (i *Informer) handleWatchError(_ *cache.Reflector, err error) {
+	if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) || err == io.EOF || err == io.ErrUnexpectedEOF {
+		// The watch closed or must restart from a fresh list; the reflector handles both
+		i.logger.Debug().Err(err).Msg("watch closed")
+		return
+	}
+	i.logger.Error().Err(err).Msg("watch failed")
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.health.Error = err.Error()
+	i.health.LastErrorTime = time.Now()
+}
+
+// recordSync records that the informer heard from the API server
+func (i *Informer) recordSync() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.health.LastSync = time.Now()
+}
+
+// recordInitialSync records the initial cache sync unless the watch failed
+// after the initial list, in which case the failure is the more recent state
+func (i *Informer) recordInitialSync(syncStart time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if !i.health.LastErrorTime.After(syncStart) {
+		i.health.LastSync = time.Now()
+	}
+}
+
+// recordResync records a periodic resync of the cache
+func (i *Informer) recordResync() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.health.LastResync = time.Now()
+}
+
+// recordProcessed records that a worker processed an event
+func (i *Informer) recordProcessed() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.health.LastProcessed = time.Now()
+}
+
+// isResync reports whether an update was delivered by a periodic resync rather
+// than by a change of the object
+func isResync(oldObj, newObj interface{}) bool {
+	oldMeta, err := meta.Accessor(oldObj)
+	if err != nil {
+		return false
+	}
+	newMeta, err := meta.Accessor(newObj)
+	if err != nil {
+		return false
+	}
+	return oldMeta.GetResourceVersion() != "" && oldMeta.GetResourceVersion() == newMeta.GetResourceVersion()
+}
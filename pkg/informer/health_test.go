@@ -0,0 +1,116 @@
+package informer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+)
+
+// getHealth calls handler and decodes the reported health
+func getHealth(t *testing.T, handler http.Handler) (int, types.InformerHealth) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	var health types.InformerHealth
+	if err := json.NewDecoder(rec.Body).Decode(&health); err != nil {
+		t.Fatalf("failed to decode health response: %v", err)
+	}
+	return rec.Code, health
+}
+
+func TestInformerHealthTracksState(t *testing.T) {
+	dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", ResourceVersion: "1"}}
+	inf, err := NewInformer(kubefake.NewSimpleClientset(), newTestConfig())
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+
+	if code, health := getHealth(t, inf.ReadyzHandler()); code != http.StatusServiceUnavailable || health.IsReady {
+		t.Errorf("expected the informer not to be ready before it is started, got %d %+v", code, health)
+	}
+
+	startInformer(t, inf)
+	code, health := getHealth(t, inf.HealthzHandler())
+	if code != http.StatusOK || !health.IsHealthy || health.WorkersAlive != 1 || health.LastSync.IsZero() {
+		t.Errorf("expected a healthy informer after start, got %d %+v", code, health)
+	}
+	if code, health := getHealth(t, inf.ReadyzHandler()); code != http.StatusOK || !health.IsReady {
+		t.Errorf("expected a ready informer after start, got %d %+v", code, health)
+	}
+
+	// Expired watches are restarted by the reflector and do not affect health
+	inf.handleWatchError(nil, apierrors.NewResourceExpired("too old resource version"))
+	if health := inf.Health(); !health.IsHealthy || health.Error != "" {
+		t.Errorf("expected an expired watch to be ignored, got %+v", health)
+	}
+
+	inf.handleWatchError(nil, fmt.Errorf("connection reset by peer"))
+	code, health = getHealth(t, inf.HealthzHandler())
+	if code != http.StatusServiceUnavailable || health.IsHealthy || health.Error != "connection reset by peer" {
+		t.Errorf("expected a failing watch to make the informer unhealthy, got %d %+v", code, health)
+	}
+	if code, _ := getHealth(t, inf.ReadyzHandler()); code != http.StatusServiceUnavailable {
+		t.Errorf("expected a failing watch to make the informer unready, got %d", code)
+	}
+
+	// Hearing from the API server again recovers the informer
+	inf.handleAdd(dep)
+	if health := inf.Health(); !health.IsHealthy || health.LastErrorTime.IsZero() {
+		t.Errorf("expected the informer to recover after an event, got %+v", health)
+	}
+
+	inf.handleUpdate(dep, dep)
+	if health := inf.Health(); health.LastResync.IsZero() {
+		t.Errorf("expected an update without a new resourceVersion to count as resync")
+	}
+
+	if err := inf.Stop(context.Background()); err != nil {
+		t.Fatalf("failed to stop informer: %v", err)
+	}
+	if health := inf.Health(); health.IsHealthy || health.WorkersAlive != 0 {
+		t.Errorf("expected a stopped informer to be unhealthy, got %+v", health)
+	}
+}
+
+func TestInformerHealthReportsWatchErrors(t *testing.T) {
+	source := &cache.ListWatch{
+		ListFunc: func(metav1.ListOptions) (runtime.Object, error) {
+			return &appsv1.DeploymentList{ListMeta: metav1.ListMeta{ResourceVersion: "1"}}, nil
+		},
+		WatchFunc: func(metav1.ListOptions) (watch.Interface, error) {
+			return nil, fmt.Errorf("watch unavailable")
+		},
+	}
+	config := newTestConfig()
+	config.SetDefaults()
+	inf, err := newInformer("deployments.apps", cache.NewSharedIndexInformer(source, &appsv1.Deployment{}, 0, cache.Indexers{}), config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	startInformer(t, inf)
+
+	deadline := time.Now().Add(10 * time.Second)
+	for inf.Health().Error == "" {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the watch error to be reported")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if health := inf.Health(); health.IsHealthy || health.IsReady {
+		t.Errorf("expected an informer with a failing watch to be unhealthy, got %+v", health)
+	}
+}
@@ -0,0 +1,196 @@
+package informer
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
+)
+
+// Names of the indexes every informer maintains
+const (
+	// ImageIndex indexes objects by the images of their containers, both as
+	// written ("nginx:1.25") and by repository ("nginx")
+	ImageIndex = "image"
+	// OwnerIndex indexes objects by the UIDs of their owners
+	OwnerIndex = "owner"
+	// LabelIndex indexes objects by "key=value" for the label keys in
+	// InformerConfig.IndexLabels
+	LabelIndex = "label"
+)
+
+// indexers returns the built-in indexers merged with the custom ones of the config
+func (i *Informer) indexers() (cache.Indexers, error) {
+	indexers := cache.Indexers{
+		ImageIndex: imageIndexFunc,
+		OwnerIndex: ownerIndexFunc,
+	}
+	if len(i.config.IndexLabels) > 0 {
+		indexers[LabelIndex] = labelIndexFunc(i.config.IndexLabels)
+	}
+	for name, indexFunc := range i.config.Indexers {
+		if _, exists := indexers[name]; exists || name == cache.NamespaceIndex {
+			return nil, fmt.Errorf("indexer %q conflicts with a built-in indexer", name)
+		}
+		indexers[name] = indexFunc
+	}
+	return indexers, nil
+}
+
+// ByIndex lists the cached objects whose index indexName contains value
+func (i *Informer) ByIndex(indexName, value string) ([]client.Object, error) {
+	objs, err := i.indexer.ByIndex(indexName, value)
+	if err != nil {
+		return nil, errors.NewCacheError(fmt.Sprintf("failed to list %s by index %s", i.resource, indexName), err)
+	}
+	objects := make([]client.Object, len(objs))
+	for j, obj := range objs {
+		objects[j] = obj.(client.Object)
+	}
+	return objects, nil
+}
+
+// ListByImage lists the cached objects with a container running image. image
+// is either a full reference ("nginx:1.25") or a repository ("nginx").
+func (i *Informer) ListByImage(image string) ([]client.Object, error) {
+	return i.ByIndex(ImageIndex, image)
+}
+
+// ListByOwner lists the cached objects owned by the object with the given UID
+func (i *Informer) ListByOwner(uid apitypes.UID) ([]client.Object, error) {
+	return i.ByIndex(OwnerIndex, string(uid))
+}
+
+// ListByLabel lists the cached objects labelled key=value. key must be one of
+// InformerConfig.IndexLabels.
+func (i *Informer) ListByLabel(key, value string) ([]client.Object, error) {
+	indexed := false
+	for _, indexLabel := range i.config.IndexLabels {
+		indexed = indexed || indexLabel == key
+	}
+	if !indexed {
+		return nil, errors.NewCacheError(fmt.Sprintf("label %q is not indexed, add it to indexLabels", key), nil)
+	}
+	return i.ByIndex(LabelIndex, key+"="+value)
+}
+
+// imageIndexFunc indexes objects by the images of their containers
+func imageIndexFunc(obj interface{}) ([]string, error) {
+	var values []string
+	seen := map[string]bool{}
+	for _, image := range containerImages(obj) {
+		for _, value := range []string{image, imageRepository(image)} {
+			if !seen[value] {
+				seen[value] = true
+				values = append(values, value)
+			}
+		}
+	}
+	return values, nil
+}
+
+// ownerIndexFunc indexes objects by the UIDs of their owners
+func ownerIndexFunc(obj interface{}) ([]string, error) {
+	object, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	owners := object.GetOwnerReferences()
+	values := make([]string, 0, len(owners))
+	for _, owner := range owners {
+		values = append(values, string(owner.UID))
+	}
+	return values, nil
+}
+
+// labelIndexFunc indexes objects by "key=value" for the given label keys
+func labelIndexFunc(keys []string) cache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		object, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		objectLabels := object.GetLabels()
+		var values []string
+		for _, key := range keys {
+			if value, ok := objectLabels[key]; ok {
+				values = append(values, key+"="+value)
+			}
+		}
+		return values, nil
+	}
+}
+
+// containerImages returns the images of all containers of workloads and pods
+func containerImages(obj interface{}) []string {
+	var spec *corev1.PodSpec
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		spec = &o.Spec
+	case *appsv1.Deployment:
+		spec = &o.Spec.Template.Spec
+	case *appsv1.StatefulSet:
+		spec = &o.Spec.Template.Spec
+	case *appsv1.DaemonSet:
+		spec = &o.Spec.Template.Spec
+	case *appsv1.ReplicaSet:
+		spec = &o.Spec.Template.Spec
+	case *batchv1.Job:
+		spec = &o.Spec.Template.Spec
+	case *batchv1.CronJob:
+		spec = &o.Spec.JobTemplate.Spec.Template.Spec
+	case *unstructured.Unstructured:
+		return unstructuredContainerImages(o)
+	default:
+		return nil
+	}
+
+	var images []string
+	for _, c := range spec.InitContainers {
+		images = append(images, c.Image)
+	}
+	for _, c := range spec.Containers {
+		images = append(images, c.Image)
+	}
+	for _, c := range spec.EphemeralContainers {
+		images = append(images, c.Image)
+	}
+	return images
+}
+
+// unstructuredContainerImages returns the container images of custom
+// workloads that embed a pod template under spec.template
+func unstructuredContainerImages(u *unstructured.Unstructured) []string {
+	var images []string
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _, _ := unstructured.NestedSlice(u.Object, "spec", "template", "spec", field)
+		for _, c := range containers {
+			if container, ok := c.(map[string]interface{}); ok {
+				if image, ok := container["image"].(string); ok {
+					images = append(images, image)
+				}
+			}
+		}
+	}
+	return images
+}
+
+// imageRepository strips the tag and digest from an image reference
+func imageRepository(image string) string {
+	if at := strings.Index(image, "@"); at >= 0 {
+		image = image[:at]
+	}
+	if colon := strings.LastIndex(image, ":"); colon > strings.LastIndex(image, "/") {
+		image = image[:colon]
+	}
+	return image
+}
@@ -0,0 +1,126 @@
+package informer
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newIndexedDeployment(name, image, team string, owner metav1.OwnerReference) *appsv1.Deployment {
+	dep := newTestDeployment(name)
+	dep.Labels = map[string]string{"team": team}
+	dep.OwnerReferences = []metav1.OwnerReference{owner}
+	dep.Spec.Template.Spec.Containers = []corev1.Container{{Name: "app", Image: image}}
+	return dep
+}
+
+func objectNames(objects []client.Object) []string {
+	names := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		names = append(names, obj.GetName())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestInformerQueriesByIndex(t *testing.T) {
+	config := newTestConfig()
+	config.IndexLabels = []string{"team"}
+	config.Indexers = cache.Indexers{
+		"replicas": func(obj interface{}) ([]string, error) {
+			if dep, ok := obj.(*appsv1.Deployment); ok && dep.Spec.Replicas != nil && *dep.Spec.Replicas == 0 {
+				return []string{"scaled-down"}, nil
+			}
+			return nil, nil
+		},
+	}
+	inf, err := NewInformer(kubefake.NewSimpleClientset(), config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+
+	release := metav1.OwnerReference{Kind: "Release", Name: "shop", UID: "uid-shop"}
+	other := metav1.OwnerReference{Kind: "Release", Name: "blog", UID: "uid-blog"}
+	zero := int32(0)
+	idle := newIndexedDeployment("idle", "redis@sha256:abc", "search", other)
+	idle.Spec.Replicas = &zero
+	for _, dep := range []*appsv1.Deployment{
+		newIndexedDeployment("web", "nginx:1.25", "payments", release),
+		newIndexedDeployment("api", "registry.local:5000/nginx:1.24", "payments", release),
+		newIndexedDeployment("cache", "nginx:1.25", "search", other),
+		idle,
+	} {
+		if err := inf.indexer.Add(dep); err != nil {
+			t.Fatalf("failed to seed cache: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query func() ([]client.Object, error)
+		want  []string
+	}{
+		{"image reference", func() ([]client.Object, error) { return inf.ListByImage("nginx:1.25") }, []string{"cache", "web"}},
+		{"image repository", func() ([]client.Object, error) { return inf.ListByImage("nginx") }, []string{"cache", "web"}},
+		{"image with registry port", func() ([]client.Object, error) { return inf.ListByImage("registry.local:5000/nginx") }, []string{"api"}},
+		{"image digest", func() ([]client.Object, error) { return inf.ListByImage("redis") }, []string{"idle"}},
+		{"owner", func() ([]client.Object, error) { return inf.ListByOwner("uid-shop") }, []string{"api", "web"}},
+		{"label", func() ([]client.Object, error) { return inf.ListByLabel("team", "search") }, []string{"cache", "idle"}},
+		{"custom", func() ([]client.Object, error) { return inf.ByIndex("replicas", "scaled-down") }, []string{"idle"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects, err := tt.query()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := objectNames(objects); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := inf.ListByLabel("tier", "web"); err == nil {
+		t.Errorf("expected an error for a label that is not indexed")
+	}
+	if _, err := inf.ByIndex("unknown", "value"); err == nil {
+		t.Errorf("expected an error for an unknown index")
+	}
+}
+
+func TestInformerRejectsConflictingIndexers(t *testing.T) {
+	config := newTestConfig()
+	config.Indexers = cache.Indexers{ImageIndex: imageIndexFunc}
+	if _, err := NewInformer(kubefake.NewSimpleClientset(), config); err == nil {
+		t.Errorf("expected an error for an indexer named like a built-in one")
+	}
+}
+
+func TestImageIndexFuncReadsUnstructuredPodTemplates(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"initContainers": []interface{}{map[string]interface{}{"image": "busybox"}},
+					"containers":     []interface{}{map[string]interface{}{"image": "nginx:1.25"}},
+				},
+			},
+		},
+	}}
+	values, err := imageIndexFunc(u)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"busybox", "nginx:1.25", "nginx"}
+	if strings.Join(values, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v, got %v", want, values)
+	}
+}
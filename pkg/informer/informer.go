@@ -2,52 +2,90 @@ package informer
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/thegostev/go-kubernetes-controllers/internal/types"
 	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
 )
 
-// Informer represents a Kubernetes deployment informer
+// Informer watches a single Kubernetes resource and caches its objects.
+// Objects are cached as typed objects (for example *appsv1.Deployment) when
+// their kind is known to the scheme and as *unstructured.Unstructured otherwise.
+//
+// Events are queued by object key in a rate-limited workqueue, so no event is
+// lost when workers fall behind: events for the same key are merged while they
+// wait and workers always process the latest object from the cache.
 type Informer struct {
-	clientset  *kubernetes.Clientset
-	config     *types.InformerConfig
-	logger     zerolog.Logger
-	indexer    cache.Indexer
-	informer   cache.SharedIndexInformer
-	eventQueue chan types.Event
-	health     *types.InformerHealth
-	mu         sync.RWMutex
-	ctx        context.Context
-	cancel     context.CancelFunc
-	workers    []*EventWorker
-	wg         sync.WaitGroup
-}
-
-// NewInformer creates a new deployment informer
-func NewInformer(clientset *kubernetes.Clientset, config *types.InformerConfig) (*Informer, error) {
-	logger := log.With().Str("component", "informer").Logger()
+	resource string
+	config   *types.InformerConfig
+	logger   zerolog.Logger
+	indexer  cache.Indexer
+	informer cache.SharedIndexInformer
+	queue    workqueue.RateLimitingInterface
+	health   *types.InformerHealth
+	stats    EventStats
+	// workersAlive counts the running worker goroutines
+	workersAlive atomic.Int32
+	// budget counts the cached objects against MaxCacheSize; it is shared by
+	// the informers of a MultiNamespaceInformer
+	budget *cacheBudget
+	mu     sync.RWMutex
+	// ctx is canceled by Stop, or with the failure as its cause when the
+	// informer stops on its own
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+	workers []*EventWorker
+	wg      sync.WaitGroup
 
-	if err := config.Validate(); err != nil {
-		logger.Error().Err(err).Msg("invalid informer configuration")
-		return nil, errors.NewConfigError("invalid informer configuration", err)
-	}
-	config.SetDefaults()
+	// pending holds the events waiting in the queue for each key, oldest
+	// first; deletes are never merged with the events that follow them
+	pending   map[string][]pendingEvent
+	pendingMu sync.Mutex
+	// handlers receive every event built by the workers
+	handlers []EventHandler
+	// process handles the events built by the workers
+	process func(ctx context.Context, event types.Event) error
+	// listPage lists one page of objects for the cache size check; nil
+	// skips the check before the initial list
+	listPage func(ctx context.Context, options metav1.ListOptions) (items int, continueToken string, err error)
+}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	eventQueue := make(chan types.Event, config.EventBufferSize)
-	health := &types.InformerHealth{
-		IsHealthy: true,
-		LastSync:  time.Now(),
-		Workers:   config.Workers,
+// pendingEvent is the event waiting in the queue for a key
+type pendingEvent struct {
+	eventType string
+	// object is the object seen by the handler; it is only used for deletes,
+	// other events read the latest object from the cache
+	object            client.Object
+	finalStateUnknown bool
+	timestamp         time.Time
+}
+
+// NewInformer creates a new deployment informer. The clientset keeps its own
+// connection limits, config.MaxConnections is not applied to it.
+func NewInformer(clientset kubernetes.Interface, config *types.InformerConfig) (*Informer, error) {
+	if err := prepareConfig(config); err != nil {
+		return nil, err
 	}
 
 	// Use the shared informer factory for deployments
@@ -55,28 +93,148 @@ func NewInformer(clientset *kubernetes.Clientset, config *types.InformerConfig)
 		clientset,
 		config.ResyncPeriod,
 		informers.WithNamespace(config.Namespace),
+		informers.WithTweakListOptions(selectorTweak(config)),
 	)
-	deploymentInformer := factory.Apps().V1().Deployments().Informer()
+	deploymentsResource := appsv1.SchemeGroupVersion.WithResource("deployments").GroupResource()
+	informer, err := newInformer(deploymentsResource.String(), factory.Apps().V1().Deployments().Informer(), config)
+	if err != nil {
+		return nil, err
+	}
+	informer.listPage = func(ctx context.Context, options metav1.ListOptions) (int, string, error) {
+		list, err := clientset.AppsV1().Deployments(config.Namespace).List(ctx, options)
+		if err != nil {
+			return 0, "", err
+		}
+		return len(list.Items), list.Continue, nil
+	}
+	return informer, nil
+}
+
+// NewResourceInformer creates an informer for any built-in or custom resource.
+// Objects are listed and watched through the dynamic client and converted to
+// their typed form using the scheme returned by k8s.NewScheme. The dynamic
+// client keeps its own connection limits, config.MaxConnections is only applied
+// by NewResourceInformerForConfig.
+func NewResourceInformer(dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, config *types.InformerConfig) (*Informer, error) {
+	if err := prepareConfig(config); err != nil {
+		return nil, err
+	}
+
+	resourceInformer := dynamicinformer.NewFilteredDynamicInformer(
+		dynamicClient,
+		gvr,
+		config.Namespace,
+		config.ResyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		selectorTweak(config),
+	).Informer()
+	if err := resourceInformer.SetTransform(typedTransform(k8s.NewScheme())); err != nil {
+		return nil, errors.NewConfigError("failed to set informer transform", err)
+	}
+
+	informer, err := newInformer(gvr.GroupResource().String(), resourceInformer, config)
+	if err != nil {
+		return nil, err
+	}
+	informer.listPage = func(ctx context.Context, options metav1.ListOptions) (int, string, error) {
+		list, err := dynamicClient.Resource(gvr).Namespace(config.Namespace).List(ctx, options)
+		if err != nil {
+			return 0, "", err
+		}
+		return len(list.Items), list.GetContinue(), nil
+	}
+	return informer, nil
+}
+
+// NewResourceInformerForConfig creates an informer for any built-in or custom
+// resource with its own dynamic client. The client has at most
+// config.MaxConnections requests to the API server in flight, not counting
+// the watch, and is rate limited accordingly, see k8s.WithConnectionLimits.
+func NewResourceInformerForConfig(restConfig *rest.Config, gvr schema.GroupVersionResource, config *types.InformerConfig) (*Informer, error) {
+	if err := prepareConfig(config); err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(k8s.WithConnectionLimits(restConfig, config.MaxConnections))
+	if err != nil {
+		return nil, errors.NewConnectionError("failed to create dynamic client", err)
+	}
+	return NewResourceInformer(dynamicClient, gvr, config)
+}
+
+// prepareConfig defaults and validates the informer configuration
+func prepareConfig(config *types.InformerConfig) error {
+	config.SetDefaults()
+	if err := config.Validate(); err != nil {
+		log.Error().Str("component", "informer").Err(err).Msg("invalid informer configuration")
+		return errors.NewConfigError("invalid informer configuration", err)
+	}
+	return nil
+}
+
+// selectorTweak restricts the listed and watched objects to the selectors of
+// the config. Objects that stop matching are reported as deleted.
+func selectorTweak(config *types.InformerConfig) func(*metav1.ListOptions) {
+	return func(options *metav1.ListOptions) {
+		options.LabelSelector = config.LabelSelector
+		options.FieldSelector = config.FieldSelector
+	}
+}
+
+// newInformer wires the event handlers and workers around a shared informer
+func newInformer(resource string, sharedInformer cache.SharedIndexInformer, config *types.InformerConfig) (*Informer, error) {
+	logger := log.With().Str("component", "informer").Str("resource", resource).Logger()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	queue := workqueue.NewRateLimitingQueueWithConfig(
+		workqueue.DefaultControllerRateLimiter(),
+		workqueue.RateLimitingQueueConfig{Name: "informer-" + resource},
+	)
+	health := &types.InformerHealth{
+		Workers:      config.Workers,
+		MaxCacheSize: config.MaxCacheSize,
+	}
 
 	informer := &Informer{
-		clientset:  clientset,
-		config:     config,
-		logger:     logger,
-		informer:   deploymentInformer,
-		indexer:    deploymentInformer.GetIndexer(),
-		eventQueue: eventQueue,
-		health:     health,
-		ctx:        ctx,
-		cancel:     cancel,
+		resource: resource,
+		config:   config,
+		logger:   logger,
+		informer: sharedInformer,
+		indexer:  sharedInformer.GetIndexer(),
+		queue:    queue,
+		health:   health,
+		ctx:      ctx,
+		cancel:   cancel,
+		budget:   newCacheBudget(config.MaxCacheSize),
+		pending:  make(map[string][]pendingEvent),
+	}
+	informer.budget.attach(informer)
+	informer.process = informer.dispatch
+
+	indexers, err := informer.indexers()
+	if err == nil {
+		err = sharedInformer.AddIndexers(indexers)
+	}
+	if err != nil {
+		cancel(nil)
+		logger.Error().Err(err).Msg("failed to add indexers")
+		return nil, errors.NewConfigError("failed to add indexers", err)
+	}
+
+	if err := sharedInformer.SetWatchErrorHandler(informer.handleWatchError); err != nil {
+		cancel(nil)
+		logger.Error().Err(err).Msg("failed to set watch error handler")
+		return nil, errors.NewConfigError("failed to set watch error handler", err)
 	}
 
 	// Register event handlers
-	_, err := deploymentInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+	_, err = sharedInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    informer.handleAdd,
 		UpdateFunc: informer.handleUpdate,
 		DeleteFunc: informer.handleDelete,
 	})
 	if err != nil {
+		cancel(nil)
 		logger.Error().Err(err).Msg("failed to add event handlers")
 		return nil, errors.NewConfigError("failed to add event handlers", err)
 	}
@@ -84,11 +242,13 @@ func NewInformer(clientset *kubernetes.Clientset, config *types.InformerConfig)
 	// Create event workers
 	informer.workers = make([]*EventWorker, config.Workers)
 	for j := 0; j < config.Workers; j++ {
-		informer.workers[j] = NewEventWorker(eventQueue, logger)
+		informer.workers[j] = NewEventWorker(queue, informer.syncKey, config.MaxRetries, &informer.stats, logger)
 	}
 
 	logger.Info().
 		Str("namespace", config.Namespace).
+		Str("labelSelector", config.LabelSelector).
+		Str("fieldSelector", config.FieldSelector).
 		Dur("resyncPeriod", config.ResyncPeriod).
 		Int("workers", config.Workers).
 		Msg("informer initialized successfully")
@@ -96,28 +256,102 @@ func NewInformer(clientset *kubernetes.Clientset, config *types.InformerConfig)
 	return informer, nil
 }
 
-// Start starts the informer
+// typedTransform converts unstructured objects to their typed form when the
+// scheme knows their kind, so cached objects can be type-asserted by callers
+func typedTransform(scheme *runtime.Scheme) cache.TransformFunc {
+	return func(obj interface{}) (interface{}, error) {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return obj, nil
+		}
+		typed, err := scheme.New(u.GroupVersionKind())
+		if err != nil {
+			// Unknown kinds are cached as unstructured objects
+			return u, nil
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, typed); err != nil {
+			return nil, fmt.Errorf("failed to convert %s %s/%s: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+		}
+		return typed, nil
+	}
+}
+
+// Resource returns the resource watched by the informer, for example "deployments.apps"
+func (i *Informer) Resource() string {
+	return i.resource
+}
+
+// Done returns a channel that is closed once the informer stopped, either
+// because Stop was called or because it failed while running, see Err
+func (i *Informer) Done() <-chan struct{} {
+	return i.ctx.Done()
+}
+
+// Err returns the failure that stopped the informer, for example a
+// *errors.CacheError when the refuse cache size policy stopped it. It returns
+// nil while the informer runs and after Stop.
+func (i *Informer) Err() error {
+	if err := context.Cause(i.ctx); !stderrors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// Start starts the informer. With the refuse cache size policy the objects
+// are counted page by page before the cache is filled, so an informer over
+// the limit fails without holding them all in memory; servers that ignore the
+// page limit are caught once the cache has synced.
 func (i *Informer) Start(ctx context.Context) error {
 	i.logger.Info().Msg("starting informer")
 
+	if err := i.checkCacheLimit(ctx); err != nil {
+		i.cancel(err)
+		return err
+	}
+
+	// Stop everything started here when ctx is done or Stop is called
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		defer cancel()
+		select {
+		case <-ctx.Done():
+		case <-i.ctx.Done():
+		}
+		i.queue.ShutDown()
+	}()
+
 	// Start event workers
 	for _, worker := range i.workers {
 		i.wg.Add(1)
+		i.workersAlive.Add(1)
 		go func(w *EventWorker) {
 			defer i.wg.Done()
+			defer i.workersAlive.Add(-1)
 			w.Start(ctx)
 		}(worker)
 	}
 
 	// Start informer
+	syncStart := time.Now()
 	go func() {
 		i.informer.Run(ctx.Done())
 	}()
 
 	// Wait for cache sync
 	if !cache.WaitForCacheSync(ctx.Done(), i.informer.HasSynced) {
+		if i.budget.exceeded() && i.config.CacheSizePolicy == types.CacheSizePolicyRefuse {
+			return errors.NewCacheError(i.cacheLimitMessage(), nil)
+		}
 		return errors.NewWatchError("failed to sync cache", nil)
 	}
+	// Event handlers may lag behind the synced store, so count the store itself
+	if size := len(i.indexer.ListKeys()); i.budget.exceededWith(i, size) && i.config.CacheSizePolicy == types.CacheSizePolicyRefuse {
+		i.logger.Error().Int("cacheSize", size).Int("maxCacheSize", i.config.MaxCacheSize).Msg("cache exceeds maxCacheSize, refusing to start")
+		err := errors.NewCacheError(i.cacheLimitMessage(), nil)
+		i.cancel(err)
+		return err
+	}
+	i.recordInitialSync(syncStart)
 
 	i.logger.Info().Msg("informer started successfully")
 	return nil
@@ -126,8 +360,8 @@ func (i *Informer) Start(ctx context.Context) error {
 // Stop stops the informer
 func (i *Informer) Stop(ctx context.Context) error {
 	i.logger.Info().Msg("stopping informer")
-	i.cancel()
-	close(i.eventQueue)
+	i.cancel(nil)
+	i.queue.ShutDown()
 	done := make(chan struct{})
 	go func() {
 		i.wg.Wait()
@@ -142,100 +376,322 @@ func (i *Informer) Stop(ctx context.Context) error {
 	}
 }
 
-// Health returns the informer health status
-func (i *Informer) Health() types.InformerHealth {
-	i.mu.RLock()
-	defer i.mu.RUnlock()
-	health := *i.health
-	health.CacheSize = len(i.indexer.ListKeys())
-	return health
+// trackCacheSize counts objects entering and leaving the cache and applies the
+// cache size policy when the count of the cache budget crosses MaxCacheSize
+func (i *Informer) trackCacheSize(delta int64) {
+	size, over, crossed := i.budget.add(i, delta)
+	if !crossed {
+		return
+	}
+
+	i.mu.Lock()
+	i.health.CacheLimitExceeded = over
+	i.mu.Unlock()
+	if !over {
+		i.logger.Info().Int64("cacheSize", size).Int("maxCacheSize", i.config.MaxCacheSize).Msg("cache size back under maxCacheSize")
+		return
+	}
+
+	if i.config.CacheSizePolicy != types.CacheSizePolicyRefuse {
+		i.logger.Warn().Int64("cacheSize", size).Int("maxCacheSize", i.config.MaxCacheSize).Msg("cache exceeds maxCacheSize")
+		return
+	}
+	i.logger.Error().Int64("cacheSize", size).Int("maxCacheSize", i.config.MaxCacheSize).Msg("cache exceeds maxCacheSize, stopping informer")
+	i.mu.Lock()
+	i.health.Error = i.cacheLimitMessage()
+	i.health.LastErrorTime = time.Now()
+	i.mu.Unlock()
+	i.cancel(errors.NewCacheError(i.cacheLimitMessage(), nil))
 }
 
-// handleAdd handles deployment add events
-func (i *Informer) handleAdd(obj interface{}) {
-	deployment := obj.(*appsv1.Deployment)
-	event := types.Event{
-		Type:      "add",
-		Namespace: deployment.Namespace,
-		Name:      deployment.Name,
-		Timestamp: time.Now(),
-		Object:    deployment,
+// checkCacheLimit counts the matching objects page by page under the refuse
+// cache size policy and fails once there are more than the cache budget has
+// room for, so at most one page is held in memory
+func (i *Informer) checkCacheLimit(ctx context.Context) error {
+	if i.config.CacheSizePolicy != types.CacheSizePolicyRefuse || i.listPage == nil {
+		return nil
 	}
-	i.logger.Debug().
-		Str("type", "add").
-		Str("namespace", deployment.Namespace).
-		Str("name", deployment.Name).
-		Msg("deployment added")
-	select {
-	case i.eventQueue <- event:
-	default:
-		i.logger.Warn().Msg("event queue full, dropping add event")
+
+	limit := i.budget.remaining()
+	options := metav1.ListOptions{Limit: int64(limit) + 1}
+	selectorTweak(i.config)(&options)
+	count := 0
+	for {
+		items, continueToken, err := i.listPage(ctx, options)
+		if err != nil {
+			return errors.NewWatchError("failed to count objects for maxCacheSize", err)
+		}
+		count += items
+		if count > limit {
+			i.logger.Error().Int("maxCacheSize", i.config.MaxCacheSize).Msg("objects exceed maxCacheSize, refusing to start")
+			i.mu.Lock()
+			i.health.CacheLimitExceeded = true
+			i.health.Error = i.cacheLimitMessage()
+			i.health.LastErrorTime = time.Now()
+			i.mu.Unlock()
+			return errors.NewCacheError(i.cacheLimitMessage(), nil)
+		}
+		// Pages of filtered lists may be partly filled, so follow them to the end
+		if continueToken == "" {
+			return nil
+		}
+		options.Continue = continueToken
 	}
 }
 
-// handleUpdate handles deployment update events
+// cacheLimitMessage describes an exceeded MaxCacheSize
+func (i *Informer) cacheLimitMessage() string {
+	return fmt.Sprintf("cache holds more than maxCacheSize %d %s", i.config.MaxCacheSize, i.resource)
+}
+
+// handleAdd handles object add events
+func (i *Informer) handleAdd(obj interface{}) {
+	i.recordSync()
+	i.trackCacheSize(1)
+	i.enqueue("add", obj)
+}
+
+// handleUpdate handles object update events, including periodic resyncs
 func (i *Informer) handleUpdate(oldObj, newObj interface{}) {
-	newDeployment := newObj.(*appsv1.Deployment)
-	event := types.Event{
-		Type:      "update",
-		Namespace: newDeployment.Namespace,
-		Name:      newDeployment.Name,
-		Timestamp: time.Now(),
-		Object:    newDeployment,
-	}
-	i.logger.Debug().
-		Str("type", "update").
-		Str("namespace", newDeployment.Namespace).
-		Str("name", newDeployment.Name).
-		Msg("deployment updated")
-	select {
-	case i.eventQueue <- event:
-	default:
-		i.logger.Warn().Msg("event queue full, dropping update event")
+	if isResync(oldObj, newObj) {
+		i.recordResync()
+	} else {
+		i.recordSync()
 	}
+	i.enqueue("update", newObj)
 }
 
-// handleDelete handles deployment delete events
+// handleDelete handles object delete events. When the watch missed the delete
+// and the object only disappeared from a relist, client-go hands over a
+// DeletedFinalStateUnknown tombstone carrying the last object known to the cache.
 func (i *Informer) handleDelete(obj interface{}) {
-	deployment := obj.(*appsv1.Deployment)
-	event := types.Event{
-		Type:      "delete",
-		Namespace: deployment.Namespace,
-		Name:      deployment.Name,
-		Timestamp: time.Now(),
-		Object:    deployment,
+	i.recordSync()
+	i.trackCacheSize(-1)
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		i.logger.Debug().Str("key", tombstone.Key).Msg("delete observed through tombstone, final state unknown")
+		i.enqueueEvent("delete", tombstone.Obj, true)
+		return
+	}
+	i.enqueue("delete", obj)
+}
+
+// enqueue hands an event for obj to the workers
+func (i *Informer) enqueue(eventType string, obj interface{}) {
+	i.enqueueEvent(eventType, obj, false)
+}
+
+// enqueueEvent queues the key of obj. If an add or update for the key is
+// already waiting it is merged with this one instead of being processed
+// twice. A waiting delete is never merged: an add after it, for an object
+// recreated with the same key, is delivered after the delete.
+// finalStateUnknown flags deletes whose object may be stale.
+func (i *Informer) enqueueEvent(eventType string, obj interface{}, finalStateUnknown bool) {
+	object, ok := obj.(client.Object)
+	if !ok {
+		i.stats.Dropped.Add(1)
+		i.logger.Error().Str("type", eventType).Msgf("unexpected object type %T, dropping event", obj)
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(object)
+	if err != nil {
+		i.stats.Dropped.Add(1)
+		i.logger.Error().Err(err).Str("type", eventType).Msg("failed to get object key, dropping event")
+		return
+	}
+
+	event := pendingEvent{
+		eventType:         eventType,
+		object:            object,
+		finalStateUnknown: finalStateUnknown,
+		timestamp:         time.Now(),
 	}
+	i.pendingMu.Lock()
+	i.pending[key] = i.appendPending(i.pending[key], event)
+	i.pendingMu.Unlock()
+
 	i.logger.Debug().
-		Str("type", "delete").
-		Str("namespace", deployment.Namespace).
-		Str("name", deployment.Name).
-		Msg("deployment deleted")
-	select {
-	case i.eventQueue <- event:
-	default:
-		i.logger.Warn().Msg("event queue full, dropping delete event")
+		Str("type", eventType).
+		Str("key", key).
+		Bool("finalStateUnknown", finalStateUnknown).
+		Msg("object changed")
+	i.queue.Add(key)
+}
+
+// appendPending adds event behind the events waiting for a key, merging it
+// into the last one unless that is a delete. pendingMu must be held.
+func (i *Informer) appendPending(waiting []pendingEvent, event pendingEvent) []pendingEvent {
+	last := len(waiting) - 1
+	if last < 0 || waiting[last].eventType == "delete" {
+		return append(waiting, event)
 	}
+	i.stats.Coalesced.Add(1)
+	event.eventType = mergeEventTypes(waiting[last].eventType, event.eventType)
+	waiting[last] = event
+	return waiting
 }
 
-// GetDeployment retrieves a deployment from cache
-func (i *Informer) GetDeployment(namespace, name string) (*appsv1.Deployment, error) {
-	key := namespace + "/" + name
+// mergeEventTypes returns the type of a waiting add or update after a newer
+// event for the same key was merged into it. An object added and then updated
+// before it was processed is still reported as added; otherwise the newer
+// type wins.
+func mergeEventTypes(waiting, newer string) string {
+	if waiting == "add" && newer == "update" {
+		return "add"
+	}
+	return newer
+}
+
+// syncKey processes the oldest event waiting for key. It is called by the workers.
+func (i *Informer) syncKey(ctx context.Context, key string, lastAttempt bool) error {
+	i.pendingMu.Lock()
+	waiting := i.pending[key]
+	if len(waiting) == 0 {
+		i.pendingMu.Unlock()
+		// The event was already processed with an earlier delivery of the key
+		return nil
+	}
+	pending := waiting[0]
+	if len(waiting) == 1 {
+		delete(i.pending, key)
+	} else {
+		i.pending[key] = waiting[1:]
+	}
+	i.pendingMu.Unlock()
+
+	err := i.syncEvent(ctx, key, pending)
+	i.recordProcessed()
+	if err != nil && !lastAttempt {
+		i.restorePending(key, pending)
+		return err
+	}
+
+	// Deliver the events queued behind this one, such as an add after a delete
+	i.pendingMu.Lock()
+	more := len(i.pending[key]) > 0
+	i.pendingMu.Unlock()
+	if more {
+		i.queue.Add(key)
+	}
+	return err
+}
+
+// restorePending puts a failed event back in front for its retry. A failed
+// add or update is merged with an event that arrived for the key in the
+// meantime; a failed delete is kept apart so it is still delivered.
+func (i *Informer) restorePending(key string, failed pendingEvent) {
+	i.pendingMu.Lock()
+	defer i.pendingMu.Unlock()
+	waiting := i.pending[key]
+	if len(waiting) > 0 && failed.eventType != "delete" {
+		waiting[0].eventType = mergeEventTypes(failed.eventType, waiting[0].eventType)
+		return
+	}
+	i.pending[key] = append([]pendingEvent{failed}, waiting...)
+}
+
+// syncEvent builds the event for key from the latest cached object and processes it
+func (i *Informer) syncEvent(ctx context.Context, key string, pending pendingEvent) error {
+	object := pending.object
+	if pending.eventType != "delete" {
+		obj, exists, err := i.indexer.GetByKey(key)
+		if err != nil {
+			return errors.NewCacheError(fmt.Sprintf("failed to get %s %s from cache", i.resource, key), err)
+		}
+		if !exists {
+			// The object was deleted after the event was queued; its delete event follows
+			return nil
+		}
+		object = obj.(client.Object)
+	}
+
+	return i.process(ctx, types.Event{
+		Type:              pending.eventType,
+		Resource:          i.resource,
+		Namespace:         object.GetNamespace(),
+		Name:              object.GetName(),
+		Timestamp:         pending.timestamp,
+		Object:            object,
+		FinalStateUnknown: pending.finalStateUnknown,
+	})
+}
+
+// AddEventHandler registers a handler for all events of the informer. Handlers
+// are called in registration order; if any of them fails, the event is retried.
+// Use Filtered and Chain to restrict a handler to some events and to wrap it
+// in middleware.
+func (i *Informer) AddEventHandler(handler EventHandler) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers = append(i.handlers, handler)
+}
+
+// dispatch passes an event to all registered handlers
+func (i *Informer) dispatch(ctx context.Context, event types.Event) error {
+	i.mu.RLock()
+	handlers := i.handlers
+	i.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler.Handle(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return stderrors.Join(errs...)
+}
+
+// Get retrieves an object from cache. Cluster-scoped objects have an empty namespace.
+func (i *Informer) Get(namespace, name string) (client.Object, error) {
+	key := name
+	if namespace != "" {
+		key = namespace + "/" + name
+	}
 	obj, exists, err := i.indexer.GetByKey(key)
 	if err != nil {
-		return nil, errors.NewCacheError("failed to get deployment from cache", err)
+		return nil, errors.NewCacheError(fmt.Sprintf("failed to get %s from cache", i.resource), err)
 	}
 	if !exists {
-		return nil, errors.NewCacheError("deployment not found in cache", nil)
+		return nil, errors.NewCacheError(fmt.Sprintf("%s %s not found in cache", i.resource, key), nil)
 	}
-	return obj.(*appsv1.Deployment), nil
+	return obj.(client.Object), nil
+}
+
+// List lists all objects in cache
+func (i *Informer) List() ([]client.Object, error) {
+	objs := i.indexer.List()
+	objects := make([]client.Object, len(objs))
+	for j, obj := range objs {
+		objects[j] = obj.(client.Object)
+	}
+	return objects, nil
+}
+
+// GetDeployment retrieves a deployment from cache
+func (i *Informer) GetDeployment(namespace, name string) (*appsv1.Deployment, error) {
+	obj, err := i.Get(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		return nil, errors.NewCacheError(fmt.Sprintf("informer caches %s, not deployments", i.resource), nil)
+	}
+	return deployment, nil
 }
 
 // ListDeployments lists all deployments in cache
 func (i *Informer) ListDeployments() ([]*appsv1.Deployment, error) {
-	objs := i.indexer.List()
-	deployments := make([]*appsv1.Deployment, len(objs))
-	for i, obj := range objs {
-		deployments[i] = obj.(*appsv1.Deployment)
+	objs, err := i.List()
+	if err != nil {
+		return nil, err
+	}
+	deployments := make([]*appsv1.Deployment, 0, len(objs))
+	for _, obj := range objs {
+		deployment, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			return nil, errors.NewCacheError(fmt.Sprintf("informer caches %s, not deployments", i.resource), nil)
+		}
+		deployments = append(deployments, deployment)
 	}
 	return deployments, nil
 }
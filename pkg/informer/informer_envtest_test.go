@@ -48,12 +48,12 @@ func TestInformerReceivesDeploymentEvents(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 
 	informerConfig := &types.InformerConfig{
-		Namespace:       "default",
-		ResyncPeriod:    1 * time.Second,
-		Workers:         1,
-		MaxCacheSize:    100,
-		MaxConnections:  5,
-		EventBufferSize: 10,
+		Namespace:      "default",
+		ResyncPeriod:   1 * time.Second,
+		Workers:        1,
+		MaxCacheSize:   100,
+		MaxConnections: 5,
+		MaxRetries:     3,
 	}
 
 	inf, err := NewInformer(clientset, informerConfig)
@@ -0,0 +1,580 @@
+package informer
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	fcache "k8s.io/client-go/tools/cache/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1beta1"
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+)
+
+func newTestConfig() *types.InformerConfig {
+	return &types.InformerConfig{
+		Namespace:    "default",
+		ResyncPeriod: time.Minute,
+		Workers:      1,
+	}
+}
+
+// startInformer starts the informer and waits for its cache to sync
+func startInformer(t *testing.T, inf *Informer) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	if err := inf.Start(ctx); err != nil {
+		t.Fatalf("failed to start informer: %v", err)
+	}
+}
+
+func TestResourceInformerCachesTypedObjects(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "example", Namespace: "default"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(k8s.NewScheme(),
+		&appsv1.StatefulSet{ObjectMeta: meta},
+		&appsv1.DaemonSet{ObjectMeta: meta},
+		&corev1.ConfigMap{ObjectMeta: meta, Data: map[string]string{"key": "value"}},
+		&v1beta1.FrontendPage{ObjectMeta: meta, Spec: v1beta1.FrontendPageSpec{Title: "Example", Template: "dashboard"}},
+	)
+
+	tests := []struct {
+		gvr      schema.GroupVersionResource
+		resource string
+		check    func(client.Object) bool
+	}{
+		{
+			gvr:      appsv1.SchemeGroupVersion.WithResource("statefulsets"),
+			resource: "statefulsets.apps",
+			check:    func(obj client.Object) bool { _, ok := obj.(*appsv1.StatefulSet); return ok },
+		},
+		{
+			gvr:      appsv1.SchemeGroupVersion.WithResource("daemonsets"),
+			resource: "daemonsets.apps",
+			check:    func(obj client.Object) bool { _, ok := obj.(*appsv1.DaemonSet); return ok },
+		},
+		{
+			gvr:      corev1.SchemeGroupVersion.WithResource("configmaps"),
+			resource: "configmaps",
+			check: func(obj client.Object) bool {
+				cm, ok := obj.(*corev1.ConfigMap)
+				return ok && cm.Data["key"] == "value"
+			},
+		},
+		{
+			gvr:      v1beta1.GroupVersion.WithResource("frontendpages"),
+			resource: "frontendpages.frontend.thegostev.com",
+			check: func(obj client.Object) bool {
+				page, ok := obj.(*v1beta1.FrontendPage)
+				return ok && page.Spec.Title == "Example"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resource, func(t *testing.T) {
+			inf, err := NewResourceInformer(dynamicClient, tt.gvr, newTestConfig())
+			if err != nil {
+				t.Fatalf("failed to create informer: %v", err)
+			}
+			if inf.Resource() != tt.resource {
+				t.Errorf("expected resource %q, got %q", tt.resource, inf.Resource())
+			}
+			startInformer(t, inf)
+
+			obj, err := inf.Get("default", "example")
+			if err != nil {
+				t.Fatalf("expected object in cache: %v", err)
+			}
+			if !tt.check(obj) {
+				t.Errorf("unexpected cached object %#v", obj)
+			}
+			objs, err := inf.List()
+			if err != nil || len(objs) != 1 {
+				t.Errorf("expected one cached object, got %d (%v)", len(objs), err)
+			}
+			if _, err := inf.GetDeployment("default", "example"); err == nil {
+				t.Errorf("expected GetDeployment to fail for %s", tt.resource)
+			}
+		})
+	}
+}
+
+func TestResourceInformerKeepsUnknownKindsUnstructured(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	widget := &unstructured.Unstructured{}
+	widget.SetAPIVersion("example.com/v1")
+	widget.SetKind("Widget")
+	widget.SetNamespace("default")
+	widget.SetName("example")
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "WidgetList"}, widget)
+
+	inf, err := NewResourceInformer(dynamicClient, gvr, newTestConfig())
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	startInformer(t, inf)
+
+	obj, err := inf.Get("default", "example")
+	if err != nil {
+		t.Fatalf("expected object in cache: %v", err)
+	}
+	if _, ok := obj.(*unstructured.Unstructured); !ok {
+		t.Errorf("expected an unstructured object, got %T", obj)
+	}
+}
+
+func TestInformerDeploymentWrappers(t *testing.T) {
+	clientset := kubefake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"}},
+	)
+
+	inf, err := NewInformer(clientset, newTestConfig())
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	startInformer(t, inf)
+
+	deployment, err := inf.GetDeployment("default", "web")
+	if err != nil {
+		t.Fatalf("expected deployment in cache: %v", err)
+	}
+	if deployment.Name != "web" {
+		t.Errorf("unexpected deployment %q", deployment.Name)
+	}
+	if _, err := inf.GetDeployment("default", "missing"); err == nil {
+		t.Errorf("expected an error for a missing deployment")
+	}
+
+	deployments, err := inf.ListDeployments()
+	if err != nil {
+		t.Fatalf("failed to list deployments: %v", err)
+	}
+	if len(deployments) != 1 {
+		t.Errorf("expected only the deployment in the watched namespace, got %d", len(deployments))
+	}
+}
+
+func TestInformerCoalescesEventsPerKey(t *testing.T) {
+	inf, err := NewResourceInformer(dynamicfake.NewSimpleDynamicClient(k8s.NewScheme()),
+		corev1.SchemeGroupVersion.WithResource("configmaps"), newTestConfig())
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	events := recordEvents(inf)
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default", ResourceVersion: "1"}}
+	latest := cm.DeepCopy()
+	latest.ResourceVersion = "3"
+	if err := inf.indexer.Add(latest); err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+	inf.handleAdd(cm)
+	inf.handleUpdate(cm, cm)
+	inf.handleUpdate(cm, latest)
+	if inf.queue.Len() != 1 {
+		t.Fatalf("expected one queued key, got %d", inf.queue.Len())
+	}
+
+	processNextKey(t, inf)
+	event := <-events
+	if event.Type != "add" || event.Resource != "configmaps" || event.Namespace != "default" || event.Name != "settings" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if event.Object.(client.Object).GetResourceVersion() != "3" {
+		t.Errorf("expected the latest object from the cache, got %+v", event.Object)
+	}
+
+	inf.handleDelete(latest)
+	processNextKey(t, inf)
+	if event := <-events; event.Type != "delete" || event.Object != latest || event.FinalStateUnknown {
+		t.Errorf("unexpected delete event %+v", event)
+	}
+
+	// A delete is never merged into the add of an object recreated with the
+	// same key; the update after the add is
+	recreated := latest.DeepCopy()
+	recreated.UID, recreated.ResourceVersion = "recreated", "5"
+	if err := inf.indexer.Update(recreated); err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+	inf.handleDelete(latest)
+	inf.handleAdd(recreated)
+	inf.handleUpdate(recreated, recreated)
+	if inf.queue.Len() != 1 {
+		t.Fatalf("expected one queued key, got %d", inf.queue.Len())
+	}
+	processNextKey(t, inf)
+	if event := <-events; event.Type != "delete" || event.Object != latest {
+		t.Errorf("expected the delete before the add, got %+v", event)
+	}
+	processNextKey(t, inf)
+	if event := <-events; event.Type != "add" || event.Object.(client.Object).GetUID() != "recreated" {
+		t.Errorf("expected the add of the recreated object, got %+v", event)
+	}
+
+	health := inf.Health()
+	if health.CoalescedEvents != 3 || health.DroppedEvents != 0 || health.RetriedEvents != 0 || health.QueueLength != 0 {
+		t.Errorf("unexpected event counters %+v", health)
+	}
+}
+
+func TestInformerRetriesFailedEvents(t *testing.T) {
+	config := newTestConfig()
+	config.MaxRetries = 2
+	inf, err := NewInformer(kubefake.NewSimpleClientset(), config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	if err := inf.indexer.Add(dep); err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+
+	// Fail the first attempt, then succeed
+	attempts := 0
+	inf.process = func(_ context.Context, event types.Event) error {
+		attempts++
+		if attempts == 1 {
+			return fmt.Errorf("audit sink unavailable")
+		}
+		return nil
+	}
+	inf.handleAdd(dep)
+	processNextKey(t, inf)
+	processNextKey(t, inf)
+	if attempts != 2 {
+		t.Errorf("expected the event to be retried once, got %d attempts", attempts)
+	}
+
+	// Always fail: the event is dropped after MaxRetries retries
+	attempts = 0
+	inf.process = func(context.Context, types.Event) error {
+		attempts++
+		return fmt.Errorf("audit sink unavailable")
+	}
+	inf.handleUpdate(dep, dep)
+	for j := 0; j <= config.MaxRetries; j++ {
+		processNextKey(t, inf)
+	}
+	if attempts != config.MaxRetries+1 {
+		t.Errorf("expected %d attempts, got %d", config.MaxRetries+1, attempts)
+	}
+	if len(inf.pending) != 0 || inf.queue.Len() != 0 {
+		t.Errorf("expected the dropped event to be forgotten")
+	}
+
+	health := inf.Health()
+	if health.RetriedEvents != 3 || health.DroppedEvents != 1 || health.CoalescedEvents != 0 {
+		t.Errorf("unexpected event counters %+v", health)
+	}
+}
+
+func TestInformerRetriesFailedDeleteBeforeRecreate(t *testing.T) {
+	inf, err := NewInformer(kubefake.NewSimpleClientset(), newTestConfig())
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "old"}}
+	recreated := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "new"}}
+
+	// The delete fails and the object is recreated before its retry
+	var delivered []string
+	inf.process = func(_ context.Context, event types.Event) error {
+		if event.Type == "delete" && len(delivered) == 0 {
+			delivered = append(delivered, "failed delete")
+			if err := inf.indexer.Add(recreated); err != nil {
+				t.Fatalf("failed to seed cache: %v", err)
+			}
+			inf.handleAdd(recreated)
+			return fmt.Errorf("audit sink unavailable")
+		}
+		delivered = append(delivered, event.Type+" "+string(event.Object.(client.Object).GetUID()))
+		return nil
+	}
+	inf.handleDelete(dep)
+	processNextKey(t, inf)
+	processNextKey(t, inf)
+	processNextKey(t, inf)
+
+	if got := strings.Join(delivered, ", "); got != "failed delete, delete old, add new" {
+		t.Errorf("expected the delete to be retried before the add, got %s", got)
+	}
+}
+
+func TestInformerHandlesDeletedFinalStateUnknown(t *testing.T) {
+	// The fake source lets the watch miss a delete; the following relist then
+	// reports the delete as a DeletedFinalStateUnknown tombstone
+	source := fcache.NewFakeControllerSource()
+	dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	source.Add(dep)
+
+	config := newTestConfig()
+	config.SetDefaults()
+	sharedInformer := cache.NewSharedIndexInformer(source, &appsv1.Deployment{}, 0, cache.Indexers{})
+	inf, err := newInformer("deployments.apps", sharedInformer, config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+
+	events := recordEvents(inf)
+	startInformer(t, inf)
+	if event := nextEvent(t, events); event.Type != "add" {
+		t.Fatalf("expected add event, got %+v", event)
+	}
+
+	source.DeleteDropWatch(dep)
+	source.ResetWatch()
+
+	event := nextEvent(t, events)
+	if event.Type != "delete" || !event.FinalStateUnknown {
+		t.Fatalf("expected delete event with unknown final state, got %+v", event)
+	}
+	if event.Namespace != "default" || event.Name != "web" {
+		t.Errorf("unexpected delete event for %s/%s", event.Namespace, event.Name)
+	}
+	if _, ok := event.Object.(*appsv1.Deployment); !ok {
+		t.Errorf("expected the last known deployment on the event, got %T", event.Object)
+	}
+}
+
+func TestInformerHandleDeleteIgnoresUnknownTombstones(t *testing.T) {
+	inf, err := NewInformer(kubefake.NewSimpleClientset(), newTestConfig())
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+
+	inf.handleDelete(cache.DeletedFinalStateUnknown{Key: "default/web", Obj: "not an object"})
+	if inf.queue.Len() != 0 {
+		t.Errorf("expected no queued key for an unusable tombstone")
+	}
+	if dropped := inf.Health().DroppedEvents; dropped != 1 {
+		t.Errorf("expected the unusable tombstone to be counted as dropped, got %d", dropped)
+	}
+}
+
+// recordEvents replaces the event processing of inf with one that records the events
+func recordEvents(inf *Informer) <-chan types.Event {
+	events := make(chan types.Event, 100)
+	inf.process = func(_ context.Context, event types.Event) error {
+		events <- event
+		return nil
+	}
+	return events
+}
+
+// processNextKey lets a worker process the next queued key, waiting for rate-limited retries
+func processNextKey(t *testing.T, inf *Informer) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	done := make(chan bool, 1)
+	go func() { done <- inf.workers[0].processNextKey(ctx) }()
+	select {
+	case running := <-done:
+		if !running {
+			t.Fatalf("queue was shut down")
+		}
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for a queued key")
+	}
+}
+
+// nextEvent waits for the next processed event
+func nextEvent(t *testing.T, events <-chan types.Event) types.Event {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for an event")
+		return types.Event{}
+	}
+}
+
+func newTestDeployment(name string) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+}
+
+// waitForHealth waits until the informer health satisfies cond
+func waitForHealth(t *testing.T, inf *Informer, cond func(types.InformerHealth) bool, what string) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !cond(inf.Health()) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s, health %+v", what, inf.Health())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestInformerCacheSizePolicyWarn(t *testing.T) {
+	clientset := kubefake.NewSimpleClientset(newTestDeployment("a"), newTestDeployment("b"), newTestDeployment("c"))
+	config := newTestConfig()
+	config.MaxCacheSize = 2
+	inf, err := NewInformer(clientset, config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	startInformer(t, inf)
+
+	waitForHealth(t, inf, func(h types.InformerHealth) bool { return h.CacheLimitExceeded }, "the exceeded cache limit")
+	if health := inf.Health(); !health.IsHealthy || health.MaxCacheSize != 2 {
+		t.Errorf("expected the informer to keep running with a warning, got %+v", health)
+	}
+
+	if err := clientset.AppsV1().Deployments("default").Delete(context.Background(), "c", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete deployment: %v", err)
+	}
+	waitForHealth(t, inf, func(h types.InformerHealth) bool { return !h.CacheLimitExceeded }, "the cache to be back under the limit")
+}
+
+func TestInformerCacheSizePolicyRefuse(t *testing.T) {
+	config := newTestConfig()
+	config.MaxCacheSize = 2
+	config.CacheSizePolicy = types.CacheSizePolicyRefuse
+
+	// Refuse to start with a cache that is already too large
+	inf, err := NewInformer(kubefake.NewSimpleClientset(newTestDeployment("a"), newTestDeployment("b"), newTestDeployment("c")), config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	var cacheErr *errors.CacheError
+	if err := inf.Start(context.Background()); !stderrors.As(err, &cacheErr) {
+		t.Errorf("expected the informer to refuse to start with a cache error, got %v", err)
+	}
+	if !stderrors.As(inf.Err(), &cacheErr) {
+		t.Errorf("expected the refused start to be reported by Err, got %v", inf.Err())
+	}
+
+	// Stop a running informer once the cache grows too large
+	clientset := kubefake.NewSimpleClientset(newTestDeployment("a"), newTestDeployment("b"))
+	inf, err = NewInformer(clientset, config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	startInformer(t, inf)
+	if _, err := clientset.AppsV1().Deployments("default").Create(context.Background(), newTestDeployment("c"), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create deployment: %v", err)
+	}
+	waitForHealth(t, inf, func(h types.InformerHealth) bool { return h.WorkersAlive == 0 }, "the informer to stop")
+	if health := inf.Health(); health.IsHealthy || !health.CacheLimitExceeded || health.Error == "" {
+		t.Errorf("expected a stopped, unhealthy informer reporting the limit, got %+v", health)
+	}
+	select {
+	case <-inf.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatalf("expected Done to be closed once the informer stopped")
+	}
+	if !stderrors.As(inf.Err(), &cacheErr) {
+		t.Errorf("expected the exceeded limit to be reported by Err, got %v", inf.Err())
+	}
+}
+
+func TestInformerCountsObjectsPageByPageBeforeListing(t *testing.T) {
+	config := newTestConfig()
+	config.MaxCacheSize = 2
+	config.CacheSizePolicy = types.CacheSizePolicyRefuse
+	config.LabelSelector = "team=payments"
+
+	tests := []struct {
+		name  string
+		pages []int
+		want  bool
+	}{
+		{name: "partly filled pages under the limit", pages: []int{1, 0, 1}, want: false},
+		{name: "pages over the limit", pages: []int{2, 1, 3}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inf, err := NewInformer(kubefake.NewSimpleClientset(), config)
+			if err != nil {
+				t.Fatalf("failed to create informer: %v", err)
+			}
+			var requests []metav1.ListOptions
+			inf.listPage = func(_ context.Context, options metav1.ListOptions) (int, string, error) {
+				requests = append(requests, options)
+				page := len(requests)
+				if page == len(tt.pages) {
+					return tt.pages[page-1], "", nil
+				}
+				return tt.pages[page-1], fmt.Sprintf("page-%d", page), nil
+			}
+
+			err = inf.checkCacheLimit(context.Background())
+			var cacheErr *errors.CacheError
+			if got := stderrors.As(err, &cacheErr); got != tt.want {
+				t.Fatalf("expected refused=%v, got %v", tt.want, err)
+			}
+			if !tt.want && len(requests) != len(tt.pages) {
+				t.Errorf("expected all %d pages to be listed, got %d", len(tt.pages), len(requests))
+			}
+			if tt.want && len(requests) != 2 {
+				t.Errorf("expected listing to stop at the page over the limit, got %d requests", len(requests))
+			}
+			for j, options := range requests {
+				if options.Limit != 3 || options.LabelSelector != "team=payments" {
+					t.Errorf("expected pages of 3 objects with the selector, got %+v", options)
+				}
+				if j > 0 && options.Continue != fmt.Sprintf("page-%d", j) {
+					t.Errorf("expected page %d to continue from the previous one, got %q", j+1, options.Continue)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceInformerListsWithSelectors(t *testing.T) {
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(k8s.NewScheme(),
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "default", Labels: map[string]string{"team": "payments"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "default", Labels: map[string]string{"team": "search"}}},
+	)
+	var restrictions k8stesting.ListRestrictions
+	dynamicClient.PrependReactor("list", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		restrictions = action.(k8stesting.ListAction).GetListRestrictions()
+		return false, nil, nil
+	})
+
+	config := newTestConfig()
+	config.LabelSelector = "team=payments"
+	config.FieldSelector = "metadata.namespace=default"
+	inf, err := NewResourceInformer(dynamicClient, corev1.SchemeGroupVersion.WithResource("configmaps"), config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	startInformer(t, inf)
+
+	if restrictions.Labels.String() != "team=payments" || restrictions.Fields.String() != "metadata.namespace=default" {
+		t.Errorf("expected the selectors to be sent to the server, got %+v", restrictions)
+	}
+	objs, err := inf.List()
+	if err != nil || len(objs) != 1 || objs[0].GetName() != "payments" {
+		t.Errorf("expected only the matching config map in cache, got %v (%v)", objs, err)
+	}
+}
+
+func TestInformerRejectsInvalidSelectors(t *testing.T) {
+	config := newTestConfig()
+	config.LabelSelector = "team in (payments"
+	if _, err := NewInformer(kubefake.NewSimpleClientset(), config); err == nil {
+		t.Errorf("expected an error for an invalid label selector")
+	}
+}
@@ -0,0 +1,484 @@
+package informer
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+)
+
+// namespaceStopTimeout bounds stopping the informer of a namespace that is no
+// longer selected
+const namespaceStopTimeout = 30 * time.Second
+
+// MultiNamespaceInformer watches a resource in several namespaces with one
+// Informer per namespace, so only the watched namespaces are cached and listed.
+// The namespaces are either fixed (InformerConfig.Namespaces, where "" watches
+// all namespaces with a single informer) or chosen by a label selector on the
+// Namespace objects (InformerConfig.NamespaceSelector). Selected namespaces are
+// followed while running: a namespace that gains the labels is watched from
+// then on, and one that loses them or is deleted is dropped from the cache
+// without delete events for its objects.
+//
+// MaxCacheSize and the cache size policy apply to the objects of all namespaces
+// together, while workers and retries of the config apply to each namespace.
+type MultiNamespaceInformer struct {
+	resource string
+	config   *types.InformerConfig
+	logger   zerolog.Logger
+	selector labels.Selector
+	// newInformer creates the informer of a namespace
+	newInformer func(namespace string) (*Informer, error)
+	// namespaceInformer watches the namespaces matching selector, it is nil
+	// for fixed namespaces
+	namespaceInformer cache.SharedIndexInformer
+	// budget counts the cached objects of all namespaces against MaxCacheSize
+	budget *cacheBudget
+
+	mu sync.RWMutex
+	// ctx is canceled by Stop, or with the failure as its cause when the
+	// informer of a watched namespace fails
+	ctx       context.Context
+	cancel    context.CancelCauseFunc
+	runCtx    context.Context
+	informers map[string]*namespaceInformer
+	handlers  []EventHandler
+}
+
+// namespaceInformer is the informer of a single namespace
+type namespaceInformer struct {
+	*Informer
+	// started is closed once Start returned, with its error in err
+	started chan struct{}
+	err     error
+}
+
+// NewMultiNamespaceInformer creates an informer for a resource in several
+// namespaces, see MultiNamespaceInformer. The dynamic client keeps its own
+// connection limits, config.MaxConnections is only applied by
+// NewMultiNamespaceInformerForConfig.
+func NewMultiNamespaceInformer(dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, config *types.InformerConfig) (*MultiNamespaceInformer, error) {
+	if err := prepareConfig(config); err != nil {
+		return nil, err
+	}
+	selector, err := labels.Parse(config.NamespaceSelector)
+	if err != nil {
+		return nil, errors.NewConfigError("invalid namespace selector", err)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	m := &MultiNamespaceInformer{
+		resource:  gvr.GroupResource().String(),
+		config:    config,
+		logger:    log.With().Str("component", "multi-namespace-informer").Str("resource", gvr.GroupResource().String()).Logger(),
+		selector:  selector,
+		budget:    newCacheBudget(config.MaxCacheSize),
+		ctx:       ctx,
+		cancel:    cancel,
+		informers: make(map[string]*namespaceInformer),
+	}
+	m.newInformer = func(namespace string) (*Informer, error) {
+		namespaceConfig := *config
+		namespaceConfig.Namespace = namespace
+		namespaceConfig.Namespaces = nil
+		namespaceConfig.NamespaceSelector = ""
+		return NewResourceInformer(dynamicClient, gvr, &namespaceConfig)
+	}
+
+	if config.NamespaceSelector != "" {
+		m.namespaceInformer = dynamicinformer.NewFilteredDynamicInformer(
+			dynamicClient,
+			corev1.SchemeGroupVersion.WithResource("namespaces"),
+			metav1.NamespaceAll,
+			config.ResyncPeriod,
+			cache.Indexers{},
+			func(options *metav1.ListOptions) { options.LabelSelector = config.NamespaceSelector },
+		).Informer()
+		_, err := m.namespaceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    m.handleNamespace,
+			UpdateFunc: func(_, newObj interface{}) { m.handleNamespace(newObj) },
+			DeleteFunc: m.handleNamespaceDelete,
+		})
+		if err != nil {
+			cancel(nil)
+			return nil, errors.NewConfigError("failed to add namespace event handlers", err)
+		}
+	}
+
+	m.logger.Info().
+		Strs("namespaces", m.fixedNamespaces()).
+		Str("namespaceSelector", config.NamespaceSelector).
+		Msg("multi-namespace informer initialized successfully")
+	return m, nil
+}
+
+// NewMultiNamespaceInformerForConfig creates an informer for a resource in
+// several namespaces with its own dynamic client. The informers of all
+// namespaces share a limit of config.MaxConnections requests to the API server
+// in flight; their watches are not counted, so any number of namespaces can be
+// watched.
+func NewMultiNamespaceInformerForConfig(restConfig *rest.Config, gvr schema.GroupVersionResource, config *types.InformerConfig) (*MultiNamespaceInformer, error) {
+	if err := prepareConfig(config); err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(k8s.WithConnectionLimits(restConfig, config.MaxConnections))
+	if err != nil {
+		return nil, errors.NewConnectionError("failed to create dynamic client", err)
+	}
+	return NewMultiNamespaceInformer(dynamicClient, gvr, config)
+}
+
+// Resource returns the resource watched by the informer, for example "deployments.apps"
+func (m *MultiNamespaceInformer) Resource() string {
+	return m.resource
+}
+
+// fixedNamespaces returns the namespaces watched without a selector
+func (m *MultiNamespaceInformer) fixedNamespaces() []string {
+	if m.config.NamespaceSelector != "" {
+		return nil
+	}
+	if len(m.config.Namespaces) > 0 {
+		return m.config.Namespaces
+	}
+	return []string{m.config.Namespace}
+}
+
+// AddEventHandler registers a handler for the events of all namespaces,
+// including namespaces selected later on
+func (m *MultiNamespaceInformer) AddEventHandler(handler EventHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers = append(m.handlers, handler)
+	for _, inf := range m.informers {
+		inf.AddEventHandler(handler)
+	}
+}
+
+// Start starts the informers of the initial namespaces and waits for their
+// caches to sync
+func (m *MultiNamespaceInformer) Start(ctx context.Context) error {
+	m.logger.Info().Msg("starting multi-namespace informer")
+
+	// Stop everything started here when ctx is done or Stop is called
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		defer cancel()
+		select {
+		case <-ctx.Done():
+		case <-m.ctx.Done():
+		}
+	}()
+
+	m.mu.Lock()
+	m.runCtx = ctx
+	m.mu.Unlock()
+
+	if m.namespaceInformer == nil {
+		for _, namespace := range m.fixedNamespaces() {
+			m.addNamespace(namespace)
+		}
+	} else {
+		go m.namespaceInformer.Run(ctx.Done())
+		if !cache.WaitForCacheSync(ctx.Done(), m.namespaceInformer.HasSynced) {
+			return errors.NewWatchError("failed to sync namespaces", nil)
+		}
+	}
+
+	var errs []error
+	for namespace, inf := range m.snapshot() {
+		<-inf.started
+		// Namespaces deselected while starting fail to sync, which is expected
+		if _, watched := m.snapshot()[namespace]; inf.err != nil && watched {
+			errs = append(errs, fmt.Errorf("namespace %q: %w", namespace, inf.err))
+		}
+	}
+	if err := stderrors.Join(errs...); err != nil {
+		return err
+	}
+
+	m.logger.Info().Strs("namespaces", m.Namespaces()).Msg("multi-namespace informer started successfully")
+	return nil
+}
+
+// Done returns a channel that is closed once the informer stopped, either
+// because Stop was called or because the informer of a watched namespace
+// failed, see Err
+func (m *MultiNamespaceInformer) Done() <-chan struct{} {
+	return m.ctx.Done()
+}
+
+// Err returns the failure that stopped the informer, for example a
+// *errors.CacheError when the refuse cache size policy stopped the informer
+// of a namespace. It returns nil while the informer runs and after Stop.
+func (m *MultiNamespaceInformer) Err() error {
+	if err := context.Cause(m.ctx); !stderrors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// Stop stops the informers of all namespaces
+func (m *MultiNamespaceInformer) Stop(ctx context.Context) error {
+	m.logger.Info().Msg("stopping multi-namespace informer")
+	m.cancel(nil)
+
+	var errs []error
+	for namespace, inf := range m.snapshot() {
+		if err := inf.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("namespace %q: %w", namespace, err))
+		}
+	}
+	return stderrors.Join(errs...)
+}
+
+// Namespaces returns the watched namespaces in order, "" for all namespaces
+func (m *MultiNamespaceInformer) Namespaces() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	namespaces := make([]string, 0, len(m.informers))
+	for namespace := range m.informers {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// snapshot returns the current informers by namespace
+func (m *MultiNamespaceInformer) snapshot() map[string]*namespaceInformer {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	informers := make(map[string]*namespaceInformer, len(m.informers))
+	for namespace, inf := range m.informers {
+		informers[namespace] = inf
+	}
+	return informers
+}
+
+// handleNamespace watches a namespace while it matches the selector. The
+// selector is checked again because a watch only filters by labels on servers
+// that evaluate it for every event.
+func (m *MultiNamespaceInformer) handleNamespace(obj interface{}) {
+	namespace, ok := obj.(client.Object)
+	if !ok {
+		return
+	}
+	if m.selector.Matches(labels.Set(namespace.GetLabels())) {
+		m.addNamespace(namespace.GetName())
+	} else {
+		m.removeNamespace(namespace.GetName())
+	}
+}
+
+// handleNamespaceDelete stops watching a deleted namespace or one that no
+// longer matches the selector
+func (m *MultiNamespaceInformer) handleNamespaceDelete(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		m.logger.Error().Err(err).Msg("failed to get key of deleted namespace")
+		return
+	}
+	m.removeNamespace(key)
+}
+
+// addNamespace creates and starts the informer of a namespace unless it is
+// already watched
+func (m *MultiNamespaceInformer) addNamespace(namespace string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.informers[namespace]; exists || m.runCtx == nil {
+		return
+	}
+
+	inf, err := m.newInformer(namespace)
+	if err != nil {
+		m.logger.Error().Err(err).Str("namespace", namespace).Msg("failed to create informer for namespace")
+		return
+	}
+	inf.shareBudget(m.budget)
+	for _, handler := range m.handlers {
+		inf.AddEventHandler(handler)
+	}
+	watched := &namespaceInformer{Informer: inf, started: make(chan struct{})}
+	m.informers[namespace] = watched
+
+	m.logger.Info().Str("namespace", namespace).Msg("watching namespace")
+	go func(ctx context.Context) {
+		watched.err = inf.Start(ctx)
+		close(watched.started)
+		if watched.err != nil {
+			m.logger.Error().Err(watched.err).Str("namespace", namespace).Msg("failed to start informer for namespace")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-inf.Done():
+		}
+		// Informers of deselected namespaces are stopped on purpose
+		if err := inf.Err(); err != nil && m.watches(namespace, watched) {
+			m.logger.Error().Err(err).Str("namespace", namespace).Msg("informer for namespace failed")
+			m.cancel(fmt.Errorf("namespace %q: %w", namespace, err))
+		}
+	}(m.runCtx)
+}
+
+// watches reports whether inf is still the informer of namespace
+func (m *MultiNamespaceInformer) watches(namespace string, inf *namespaceInformer) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.informers[namespace] == inf
+}
+
+// removeNamespace stops the informer of a namespace
+func (m *MultiNamespaceInformer) removeNamespace(namespace string) {
+	m.mu.Lock()
+	inf, exists := m.informers[namespace]
+	delete(m.informers, namespace)
+	m.mu.Unlock()
+	if !exists {
+		return
+	}
+
+	m.logger.Info().Str("namespace", namespace).Msg("no longer watching namespace")
+	if m.budget.release(inf.Informer) {
+		m.logger.Info().Int("maxCacheSize", m.config.MaxCacheSize).Msg("cache size back under maxCacheSize")
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), namespaceStopTimeout)
+		defer cancel()
+		if err := inf.Stop(ctx); err != nil {
+			m.logger.Error().Err(err).Str("namespace", namespace).Msg("failed to stop informer for namespace")
+		}
+	}()
+}
+
+// Get returns a cached object; cluster-scoped objects have an empty namespace
+func (m *MultiNamespaceInformer) Get(namespace, name string) (client.Object, error) {
+	informers := m.snapshot()
+	inf, ok := informers[namespace]
+	if !ok {
+		inf, ok = informers[metav1.NamespaceAll]
+	}
+	if !ok {
+		return nil, errors.NewCacheError(fmt.Sprintf("namespace %q is not watched", namespace), nil)
+	}
+	return inf.Get(namespace, name)
+}
+
+// List lists the cached objects of all namespaces
+func (m *MultiNamespaceInformer) List() ([]client.Object, error) {
+	return m.collect(func(inf *Informer) ([]client.Object, error) { return inf.List() })
+}
+
+// ByIndex lists the cached objects of all namespaces whose index indexName contains value
+func (m *MultiNamespaceInformer) ByIndex(indexName, value string) ([]client.Object, error) {
+	return m.collect(func(inf *Informer) ([]client.Object, error) { return inf.ByIndex(indexName, value) })
+}
+
+// ListByImage lists the cached objects of all namespaces with a container running image
+func (m *MultiNamespaceInformer) ListByImage(image string) ([]client.Object, error) {
+	return m.collect(func(inf *Informer) ([]client.Object, error) { return inf.ListByImage(image) })
+}
+
+// ListByOwner lists the cached objects of all namespaces owned by the object with the given UID
+func (m *MultiNamespaceInformer) ListByOwner(uid apitypes.UID) ([]client.Object, error) {
+	return m.collect(func(inf *Informer) ([]client.Object, error) { return inf.ListByOwner(uid) })
+}
+
+// ListByLabel lists the cached objects of all namespaces labelled key=value
+func (m *MultiNamespaceInformer) ListByLabel(key, value string) ([]client.Object, error) {
+	return m.collect(func(inf *Informer) ([]client.Object, error) { return inf.ListByLabel(key, value) })
+}
+
+// collect concatenates the results of list for the informers of all namespaces
+func (m *MultiNamespaceInformer) collect(list func(inf *Informer) ([]client.Object, error)) ([]client.Object, error) {
+	var objects []client.Object
+	for _, inf := range m.snapshot() {
+		namespaceObjects, err := list(inf.Informer)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, namespaceObjects...)
+	}
+	return objects, nil
+}
+
+// Health returns the combined health of the informers of all namespaces: it is
+// healthy and ready only while each of them is, and sums their sizes and counters
+func (m *MultiNamespaceInformer) Health() types.InformerHealth {
+	informers := m.snapshot()
+	health := types.InformerHealth{
+		IsHealthy:    true,
+		IsReady:      m.namespaceInformer == nil || m.namespaceInformer.HasSynced(),
+		Namespaces:   make([]string, 0, len(informers)),
+		MaxCacheSize: m.config.MaxCacheSize,
+	}
+	for namespace := range informers {
+		health.Namespaces = append(health.Namespaces, namespace)
+	}
+	sort.Strings(health.Namespaces)
+
+	for _, namespace := range health.Namespaces {
+		namespaceHealth := informers[namespace].Health()
+		health.IsHealthy = health.IsHealthy && namespaceHealth.IsHealthy
+		health.IsReady = health.IsReady && namespaceHealth.IsReady
+		if namespaceHealth.LastSync.After(health.LastSync) {
+			health.LastSync = namespaceHealth.LastSync
+		}
+		if namespaceHealth.LastResync.After(health.LastResync) {
+			health.LastResync = namespaceHealth.LastResync
+		}
+		if namespaceHealth.LastErrorTime.After(health.LastErrorTime) {
+			health.Error = fmt.Sprintf("namespace %q: %s", namespace, namespaceHealth.Error)
+			health.LastErrorTime = namespaceHealth.LastErrorTime
+		}
+		if namespaceHealth.LastProcessed.After(health.LastProcessed) {
+			health.LastProcessed = namespaceHealth.LastProcessed
+		}
+		health.CacheSize += namespaceHealth.CacheSize
+		health.Workers += namespaceHealth.Workers
+		health.WorkersAlive += namespaceHealth.WorkersAlive
+		health.QueueLength += namespaceHealth.QueueLength
+		health.DroppedEvents += namespaceHealth.DroppedEvents
+		health.RetriedEvents += namespaceHealth.RetriedEvents
+		health.CoalescedEvents += namespaceHealth.CoalescedEvents
+	}
+	// MaxCacheSize limits the objects of all namespaces together
+	health.CacheLimitExceeded = health.CacheSize > health.MaxCacheSize
+	health.IsReady = health.IsReady && health.IsHealthy
+	return health
+}
+
+// HealthzHandler serves Health as JSON with status 200 while the informers of
+// all namespaces are healthy and 503 otherwise
+func (m *MultiNamespaceInformer) HealthzHandler() http.Handler {
+	return healthHandler(m.Health, func(health types.InformerHealth) bool { return health.IsHealthy }, m.logger)
+}
+
+// ReadyzHandler serves Health as JSON with status 200 once the informers of
+// all namespaces are ready and 503 otherwise
+func (m *MultiNamespaceInformer) ReadyzHandler() http.Handler {
+	return healthHandler(m.Health, func(health types.InformerHealth) bool { return health.IsReady }, m.logger)
+}
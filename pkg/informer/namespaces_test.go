@@ -0,0 +1,292 @@
+package informer
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+)
+
+var namespacesGVR = corev1.SchemeGroupVersion.WithResource("namespaces")
+
+// newNamespacedClient returns a dynamic client with a ConfigMap "settings" in
+// each of the given namespaces, labelled team=<team>
+func newNamespacedClient(teams map[string]string) *dynamicfake.FakeDynamicClient {
+	var objects []runtime.Object
+	for namespace, team := range teams {
+		objects = append(objects,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace, Labels: map[string]string{"team": team}}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: namespace}},
+		)
+	}
+	return dynamicfake.NewSimpleDynamicClient(k8s.NewScheme(), objects...)
+}
+
+// createConfigMap creates an empty config map
+func createConfigMap(t *testing.T, dynamicClient *dynamicfake.FakeDynamicClient, namespace, name string) {
+	t.Helper()
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetName(name)
+	_, err := dynamicClient.Resource(corev1.SchemeGroupVersion.WithResource("configmaps")).Namespace(namespace).Create(context.Background(), configMap, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("failed to create config map: %v", err)
+	}
+}
+
+func startMultiNamespaceInformer(t *testing.T, inf *MultiNamespaceInformer) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	if err := inf.Start(ctx); err != nil {
+		t.Fatalf("failed to start informer: %v", err)
+	}
+}
+
+// waitForNamespaces waits until the informer watches exactly namespaces
+func waitForNamespaces(t *testing.T, inf *MultiNamespaceInformer, namespaces ...string) {
+	t.Helper()
+	want := strings.Join(namespaces, ",")
+	deadline := time.Now().Add(10 * time.Second)
+	for strings.Join(inf.Namespaces(), ",") != want || !inf.Health().IsReady {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for namespaces %s, got %v", want, inf.Namespaces())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMultiNamespaceInformerWatchesNamespaceList(t *testing.T) {
+	dynamicClient := newNamespacedClient(map[string]string{"shop": "payments", "blog": "content", "search": "search"})
+	config := newTestConfig()
+	config.Namespaces = []string{"shop", "blog"}
+	inf, err := NewMultiNamespaceInformer(dynamicClient, corev1.SchemeGroupVersion.WithResource("configmaps"), config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	startMultiNamespaceInformer(t, inf)
+
+	objects, err := inf.List()
+	if err != nil || len(objects) != 2 {
+		t.Errorf("expected the config maps of two namespaces, got %d (%v)", len(objects), err)
+	}
+	if _, err := inf.Get("blog", "settings"); err != nil {
+		t.Errorf("expected config map of a watched namespace: %v", err)
+	}
+	if _, err := inf.Get("search", "settings"); err == nil {
+		t.Errorf("expected an error for a namespace that is not watched")
+	}
+
+	health := inf.Health()
+	if !health.IsReady || health.CacheSize != 2 || health.Workers != 2 || strings.Join(health.Namespaces, ",") != "blog,shop" {
+		t.Errorf("unexpected health %+v", health)
+	}
+}
+
+func TestMultiNamespaceInformerWatchesAllNamespaces(t *testing.T) {
+	dynamicClient := newNamespacedClient(map[string]string{"shop": "payments", "blog": "content"})
+	config := newTestConfig()
+	config.Namespaces = []string{metav1.NamespaceAll}
+	inf, err := NewMultiNamespaceInformer(dynamicClient, corev1.SchemeGroupVersion.WithResource("configmaps"), config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	startMultiNamespaceInformer(t, inf)
+
+	objects, err := inf.List()
+	if err != nil || len(objects) != 2 {
+		t.Errorf("expected the config maps of all namespaces, got %d (%v)", len(objects), err)
+	}
+	if _, err := inf.Get("shop", "settings"); err != nil {
+		t.Errorf("expected config map in cache: %v", err)
+	}
+}
+
+func TestMultiNamespaceInformerFollowsNamespaceSelector(t *testing.T) {
+	dynamicClient := newNamespacedClient(map[string]string{"shop": "payments", "checkout": "search"})
+	config := newTestConfig()
+	config.NamespaceSelector = "team=payments"
+	inf, err := NewMultiNamespaceInformer(dynamicClient, corev1.SchemeGroupVersion.WithResource("configmaps"), config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	events := make(chan types.Event, 10)
+	inf.AddEventHandler(EventHandlerFunc(func(_ context.Context, event types.Event) error {
+		events <- event
+		return nil
+	}))
+	startMultiNamespaceInformer(t, inf)
+	waitForNamespaces(t, inf, "shop")
+
+	setTeam := func(namespace, team string) {
+		t.Helper()
+		ctx := context.Background()
+		ns, err := dynamicClient.Resource(namespacesGVR).Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get namespace: %v", err)
+		}
+		ns.SetLabels(map[string]string{"team": team})
+		if _, err := dynamicClient.Resource(namespacesGVR).Update(ctx, ns, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("failed to update namespace: %v", err)
+		}
+	}
+
+	// A namespace gaining the label is watched, including its existing objects
+	setTeam("checkout", "payments")
+	waitForNamespaces(t, inf, "checkout", "shop")
+	seen := map[string]bool{}
+	for len(seen) < 2 {
+		select {
+		case event := <-events:
+			seen[event.Namespace] = true
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for events, got events of %v", seen)
+		}
+	}
+
+	// A namespace losing the label is no longer watched
+	setTeam("shop", "search")
+	waitForNamespaces(t, inf, "checkout")
+	if _, err := inf.Get("shop", "settings"); err == nil {
+		t.Errorf("expected namespace shop to be dropped from the cache")
+	}
+
+	// A deleted namespace is no longer watched
+	if err := dynamicClient.Resource(namespacesGVR).Delete(context.Background(), "checkout", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete namespace: %v", err)
+	}
+	waitForNamespaces(t, inf)
+}
+
+func TestMultiNamespaceInformerRejectsNamespacesWithSelector(t *testing.T) {
+	config := newTestConfig()
+	config.Namespaces = []string{"shop"}
+	config.NamespaceSelector = "team=payments"
+	if _, err := NewMultiNamespaceInformer(newNamespacedClient(nil), namespacesGVR, config); err == nil {
+		t.Errorf("expected an error for namespaces combined with a selector")
+	}
+}
+
+func TestMultiNamespaceInformerReportsFailedNamespace(t *testing.T) {
+	dynamicClient := newNamespacedClient(map[string]string{"shop": "payments", "blog": "content"})
+	config := newTestConfig()
+	config.Namespaces = []string{"shop", "blog"}
+	config.MaxCacheSize = 2
+	config.CacheSizePolicy = types.CacheSizePolicyRefuse
+	configMaps := corev1.SchemeGroupVersion.WithResource("configmaps")
+	inf, err := NewMultiNamespaceInformer(dynamicClient, configMaps, config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	startMultiNamespaceInformer(t, inf)
+	if inf.Err() != nil {
+		t.Fatalf("expected no failure while running, got %v", inf.Err())
+	}
+
+	// A second config map in shop exceeds the cache limit of both namespaces
+	createConfigMap(t, dynamicClient, "shop", "features")
+
+	select {
+	case <-inf.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatalf("expected Done to be closed once a namespace failed")
+	}
+	var cacheErr *errors.CacheError
+	if err := inf.Err(); !stderrors.As(err, &cacheErr) || !strings.Contains(err.Error(), `namespace "shop"`) {
+		t.Errorf("expected the cache error of namespace shop, got %v", err)
+	}
+}
+
+func TestMultiNamespaceInformerSharesCacheLimit(t *testing.T) {
+	// Each namespace holds two config maps, under the limit of three on its own
+	newClient := func() *dynamicfake.FakeDynamicClient {
+		dynamicClient := newNamespacedClient(map[string]string{"shop": "payments", "blog": "content"})
+		createConfigMap(t, dynamicClient, "shop", "features")
+		createConfigMap(t, dynamicClient, "blog", "features")
+		return dynamicClient
+	}
+	configMaps := corev1.SchemeGroupVersion.WithResource("configmaps")
+
+	config := newTestConfig()
+	config.Namespaces = []string{"shop", "blog"}
+	config.MaxCacheSize = 3
+	inf, err := NewMultiNamespaceInformer(newClient(), configMaps, config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	startMultiNamespaceInformer(t, inf)
+	health := inf.Health()
+	if health.CacheSize != 4 || health.MaxCacheSize != 3 || !health.CacheLimitExceeded {
+		t.Errorf("expected the namespaces together to exceed the cache limit, got %+v", health)
+	}
+
+	refuseConfig := newTestConfig()
+	refuseConfig.Namespaces = []string{"shop", "blog"}
+	refuseConfig.MaxCacheSize = 3
+	refuseConfig.CacheSizePolicy = types.CacheSizePolicyRefuse
+	inf, err = NewMultiNamespaceInformer(newClient(), configMaps, refuseConfig)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// Namespaces starting together may only notice the shared limit once
+	// both are synced, so the failure is either returned or follows
+	_ = inf.Start(ctx)
+	select {
+	case <-inf.Done():
+	case <-ctx.Done():
+		t.Fatalf("expected Done to be closed once the cache limit was exceeded")
+	}
+	var cacheErr *errors.CacheError
+	if err := inf.Err(); !stderrors.As(err, &cacheErr) {
+		t.Errorf("expected the namespaces together to exceed the cache limit, got %v", err)
+	}
+}
+
+func TestMultiNamespaceInformerWatchesMoreNamespacesThanConnections(t *testing.T) {
+	// An HTTP/1.1 API server, where each open watch holds a connection
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("watch") == "true" {
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+		namespace := strings.Split(r.URL.Path, "/")[4]
+		fmt.Fprintf(w, `{"kind":"ConfigMapList","apiVersion":"v1","metadata":{"resourceVersion":"1"},"items":[{"metadata":{"name":"settings","namespace":%q,"resourceVersion":"1"}}]}`, namespace)
+	}))
+	defer server.Close()
+
+	config := newTestConfig()
+	config.Namespaces = []string{"shop", "blog", "search"}
+	config.MaxConnections = 1
+	inf, err := NewMultiNamespaceInformerForConfig(&rest.Config{Host: server.URL}, corev1.SchemeGroupVersion.WithResource("configmaps"), config)
+	if err != nil {
+		t.Fatalf("failed to create informer: %v", err)
+	}
+	startMultiNamespaceInformer(t, inf)
+	defer inf.Stop(context.Background())
+
+	objects, err := inf.List()
+	if err != nil || len(objects) != 3 {
+		t.Errorf("expected the config maps of three namespaces, got %d (%v)", len(objects), err)
+	}
+}
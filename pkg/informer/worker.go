@@ -2,54 +2,77 @@ package informer
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/rs/zerolog"
-
-	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+	"k8s.io/client-go/util/workqueue"
 )
 
-// EventWorker processes events asynchronously
+// SyncFunc processes the object behind a queued key. lastAttempt is set when a
+// failure will not be retried and the key is about to be dropped.
+type SyncFunc func(ctx context.Context, key string, lastAttempt bool) error
+
+// EventStats counts events that were not processed exactly once
+type EventStats struct {
+	// Dropped counts events given up on, e.g. after exhausting their retries
+	Dropped atomic.Int64
+	// Retried counts failed attempts that were requeued with backoff
+	Retried atomic.Int64
+	// Coalesced counts events merged into an event already waiting for the same key
+	Coalesced atomic.Int64
+}
+
+// EventWorker processes queued keys asynchronously
 type EventWorker struct {
-	queue  chan types.Event
-	logger zerolog.Logger
+	queue      workqueue.RateLimitingInterface
+	sync       SyncFunc
+	maxRetries int
+	stats      *EventStats
+	logger     zerolog.Logger
 }
 
-// NewEventWorker creates a new event worker
-func NewEventWorker(queue chan types.Event, logger zerolog.Logger) *EventWorker {
+// NewEventWorker creates a new event worker. Keys whose sync fails are requeued
+// with the queue's rate limiter up to maxRetries times.
+func NewEventWorker(queue workqueue.RateLimitingInterface, sync SyncFunc, maxRetries int, stats *EventStats, logger zerolog.Logger) *EventWorker {
 	return &EventWorker{
-		queue:  queue,
-		logger: logger,
+		queue:      queue,
+		sync:       sync,
+		maxRetries: maxRetries,
+		stats:      stats,
+		logger:     logger,
 	}
 }
 
-// Start starts the event worker
+// Start processes keys until the queue is shut down
 func (w *EventWorker) Start(ctx context.Context) {
 	w.logger.Debug().Msg("starting event worker")
-
-	for {
-		select {
-		case event, ok := <-w.queue:
-			if !ok {
-				w.logger.Debug().Msg("event queue closed, stopping worker")
-				return
-			}
-			w.processEvent(event)
-		case <-ctx.Done():
-			w.logger.Debug().Msg("context cancelled, stopping worker")
-			return
-		}
+	for w.processNextKey(ctx) {
 	}
+	w.logger.Debug().Msg("event queue shut down, stopping worker")
 }
 
-// processEvent processes a single event
-func (w *EventWorker) processEvent(event types.Event) {
-	w.logger.Info().
-		Str("type", event.Type).
-		Str("namespace", event.Namespace).
-		Str("name", event.Name).
-		Time("timestamp", event.Timestamp).
-		Msg("processing deployment event")
-
-	// In a real implementation, you would do more processing here
-	// For now, we just log the event
+// processNextKey processes a single key and reports whether the queue is still running
+func (w *EventWorker) processNextKey(ctx context.Context) bool {
+	item, shutdown := w.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer w.queue.Done(item)
+
+	key := item.(string)
+	lastAttempt := w.queue.NumRequeues(item) >= w.maxRetries
+	err := w.sync(ctx, key, lastAttempt)
+	switch {
+	case err == nil:
+		w.queue.Forget(item)
+	case !lastAttempt:
+		w.stats.Retried.Add(1)
+		w.logger.Warn().Err(err).Str("key", key).Int("retries", w.queue.NumRequeues(item)).Msg("failed to process event, retrying")
+		w.queue.AddRateLimited(item)
+	default:
+		w.stats.Dropped.Add(1)
+		w.logger.Error().Err(err).Str("key", key).Int("retries", w.maxRetries).Msg("failed to process event, dropping")
+		w.queue.Forget(item)
+	}
+	return true
 }
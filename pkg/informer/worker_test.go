@@ -2,17 +2,29 @@ package informer
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/rs/zerolog"
-	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+	"k8s.io/client-go/util/workqueue"
 )
 
-func TestEventWorkerProcessesEvents(t *testing.T) {
-	queue := make(chan types.Event, 1)
-	logger := zerolog.Nop()
-	worker := NewEventWorker(queue, logger)
+func TestEventWorkerProcessesKeys(t *testing.T) {
+	queue := workqueue.NewRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, 10*time.Millisecond))
+	stats := &EventStats{}
+
+	processed := make(chan string, 10)
+	failures := map[string]int{"default/flaky": 1, "default/broken": 10}
+	sync := func(_ context.Context, key string, _ bool) error {
+		if failures[key] > 0 {
+			failures[key]--
+			return fmt.Errorf("failed to process %s", key)
+		}
+		processed <- key
+		return nil
+	}
+	worker := NewEventWorker(queue, sync, 2, stats, zerolog.Nop())
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -24,16 +36,36 @@ func TestEventWorkerProcessesEvents(t *testing.T) {
 		close(done)
 	}()
 
-	event := types.Event{
-		Type:      "add",
-		Namespace: "default",
-		Name:      "test-deployment",
-		Timestamp: time.Now(),
+	for _, key := range []string{"default/web", "default/flaky", "default/broken"} {
+		queue.Add(key)
+	}
+
+	got := map[string]bool{}
+	for len(got) < 2 {
+		select {
+		case key := <-processed:
+			got[key] = true
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for processed keys, got %v", got)
+		}
+	}
+	if !got["default/web"] || !got["default/flaky"] {
+		t.Errorf("expected web and flaky to be processed, got %v", got)
+	}
+
+	// broken fails its initial attempt and both retries before it is dropped
+	deadline := time.After(5 * time.Second)
+	for stats.Dropped.Load() != 1 {
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for broken to be dropped")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if retried := stats.Retried.Load(); retried != 3 {
+		t.Errorf("expected 3 retries (1 flaky, 2 broken), got %d", retried)
 	}
 
-	queue <- event
-	// Give the worker a moment to process
-	time.Sleep(100 * time.Millisecond)
-	cancel()
+	queue.ShutDown()
 	<-done
 }
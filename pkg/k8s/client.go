@@ -10,6 +10,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/thegostev/go-kubernetes-controllers/internal/types"
 	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
@@ -17,7 +18,9 @@ import (
 
 // Client represents a Kubernetes client
 type Client struct {
-	clientset  *kubernetes.Clientset
+	clientset kubernetes.Interface
+	// ctrlClient is a typed client for the types of NewScheme, used for FrontendPages
+	ctrlClient ctrlclient.WithWatch
 	logger     zerolog.Logger
 	restConfig *rest.Config
 }
@@ -57,20 +60,38 @@ func NewClient(config *types.ClientConfig) (*Client, error) {
 		return nil, errors.NewConfigError("failed to create kubernetes clientset", err)
 	}
 
+	// Create typed client for custom resources
+	ctrlClient, err := ctrlclient.NewWithWatch(clientConfig, ctrlclient.Options{Scheme: NewScheme()})
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create typed client")
+		return nil, errors.NewConfigError("failed to create typed client", err)
+	}
+
 	logger.Info().Msg("kubernetes client initialized successfully")
 
 	return &Client{
 		clientset:  clientset,
+		ctrlClient: ctrlClient,
 		logger:     logger,
 		restConfig: clientConfig,
 	}, nil
 }
 
 // GetClientset returns the underlying kubernetes clientset
-func (c *Client) GetClientset() *kubernetes.Clientset {
+func (c *Client) GetClientset() kubernetes.Interface {
 	return c.clientset
 }
 
+// GetCtrlClient returns the typed controller-runtime client for the types of NewScheme
+func (c *Client) GetCtrlClient() ctrlclient.Client {
+	return c.ctrlClient
+}
+
+// GetRESTConfig returns the configuration the client was created from
+func (c *Client) GetRESTConfig() *rest.Config {
+	return c.restConfig
+}
+
 // HealthCheck performs a basic health check
 func (c *Client) HealthCheck(ctx context.Context) error {
 	c.logger.Debug().Msg("performing health check")
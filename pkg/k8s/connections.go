@@ -0,0 +1,86 @@
+package k8s
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"k8s.io/client-go/rest"
+)
+
+// WithConnectionLimits returns a copy of config for clients that have at most
+// maxConnections requests to the API server in flight. Requests are rate
+// limited to maxConnections per second with bursts of twice that; requests
+// made while maxConnections are in flight wait for one to finish. Watches stay
+// open for as long as they run, so they are not counted and never wait. All
+// clients created from the returned config share the limit. A non-positive
+// maxConnections leaves the client-go defaults in place.
+func WithConnectionLimits(config *rest.Config, maxConnections int) *rest.Config {
+	config = rest.CopyConfig(config)
+	if maxConnections <= 0 {
+		return config
+	}
+	config.QPS = float32(maxConnections)
+	config.Burst = 2 * maxConnections
+
+	limiter := &requestLimiter{slots: make(chan struct{}, maxConnections)}
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &limitedRoundTripper{limiter: limiter, next: rt}
+	})
+	return config
+}
+
+// requestLimiter caps the number of requests in flight through it
+type requestLimiter struct {
+	slots chan struct{}
+}
+
+// limitedRoundTripper sends requests other than watches once the limiter has
+// a free slot, and holds the slot until the response body is closed
+type limitedRoundTripper struct {
+	limiter *requestLimiter
+	next    http.RoundTripper
+}
+
+// RoundTrip sends req, waiting for a free slot unless it is a watch
+func (t *limitedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isWatch(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	select {
+	case t.limiter.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	release := sync.OnceFunc(func() { <-t.limiter.slots })
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &limitedBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// isWatch reports whether req is a watch, which stays open until it is stopped
+func isWatch(req *http.Request) bool {
+	switch req.URL.Query().Get("watch") {
+	case "true", "1":
+		return true
+	}
+	return false
+}
+
+// limitedBody frees its slot in the requestLimiter when closed
+type limitedBody struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close closes the body and frees its slot
+func (b *limitedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
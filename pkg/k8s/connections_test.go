@@ -0,0 +1,83 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestWithConnectionLimits(t *testing.T) {
+	base := &rest.Config{Host: "https://example.com"}
+
+	limited := WithConnectionLimits(base, 4)
+	if limited.QPS != 4 || limited.Burst != 8 || limited.WrapTransport == nil {
+		t.Errorf("expected QPS 4, burst 8 and a limiting transport, got %v, %d, %v", limited.QPS, limited.Burst, limited.WrapTransport != nil)
+	}
+	if base.QPS != 0 || base.Burst != 0 || base.WrapTransport != nil {
+		t.Errorf("expected the base config to be left unchanged")
+	}
+
+	unlimited := WithConnectionLimits(base, 0)
+	if unlimited.QPS != 0 || unlimited.Burst != 0 || unlimited.WrapTransport != nil {
+		t.Errorf("expected client-go defaults without a limit")
+	}
+}
+
+func TestConnectionLimitsCapRequestsInFlight(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("watch") == "true" || r.URL.Path == "/blocked" {
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-unblock
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	transport, err := rest.TransportFor(WithConnectionLimits(&rest.Config{Host: server.URL}, 1))
+	if err != nil {
+		t.Fatalf("failed to create transport: %v", err)
+	}
+	client := &http.Client{Transport: transport}
+	get := func(ctx context.Context, path string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		return client.Do(req)
+	}
+
+	// Watches are not counted, however many are open
+	for range 3 {
+		resp, err := get(context.Background(), "/watched?watch=true")
+		if err != nil {
+			t.Fatalf("expected a watch to be sent: %v", err)
+		}
+		defer resp.Body.Close()
+	}
+
+	first, err := get(context.Background(), "/blocked")
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := get(ctx, "/"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the second request to wait for the first, got %v", err)
+	}
+
+	first.Body.Close()
+	second, err := get(context.Background(), "/")
+	if err != nil {
+		t.Fatalf("expected a request to be sent once the first finished: %v", err)
+	}
+	second.Body.Close()
+}
@@ -2,22 +2,50 @@ package k8s
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 
 	"github.com/thegostev/go-kubernetes-controllers/internal/types"
 	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
 )
 
-// ListDeployments lists deployments in the specified namespace with context and timeout
+// ListDeployments lists deployments in the specified namespace with context and timeout.
+// With options.ChunkSize set they are fetched in chunks, see ListDeploymentsInChunks.
 func (c *Client) ListDeployments(ctx context.Context, options *types.ListOptions) (*appsv1.DeploymentList, error) {
+	deployments := &appsv1.DeploymentList{}
+	err := c.ListDeploymentsInChunks(ctx, options, func(chunk []appsv1.Deployment, restarted bool) error {
+		if restarted {
+			deployments.Items = nil
+		}
+		deployments.Items = append(deployments.Items, chunk...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deployments, nil
+}
+
+// ListDeploymentsInChunks lists deployments in the specified namespace in chunks
+// of options.ChunkSize, passing each chunk to fn as soon as it arrives.
+// options.Timeout bounds each request. If the list has to restart because its
+// continue token expired, the first chunk of the new list has restarted set and
+// the chunks passed before must be discarded.
+func (c *Client) ListDeploymentsInChunks(ctx context.Context, options *types.ListOptions, fn func(deployments []appsv1.Deployment, restarted bool) error) error {
 	logger := c.logger.With().Str("operation", "list-deployments").Logger()
 
 	// Validate options
 	if err := options.Validate(); err != nil {
 		logger.Error().Err(err).Msg("invalid list options")
-		return nil, errors.NewValidationError("list options", err.Error())
+		return errors.NewValidationError("list options", err.Error())
 	}
 
 	// Set defaults
@@ -26,32 +54,49 @@ func (c *Client) ListDeployments(ctx context.Context, options *types.ListOptions
 	logger.Debug().
 		Str("namespace", options.Namespace).
 		Dur("timeout", options.Timeout).
+		Str("labelSelector", options.LabelSelector).
+		Str("fieldSelector", options.FieldSelector).
+		Int64("chunkSize", options.ChunkSize).
 		Msg("listing deployments")
 
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
-	defer cancel()
-
 	// Check if context is already cancelled
 	select {
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return ctx.Err()
 	default:
 	}
 
 	// List deployments
-	deployments, err := c.clientset.AppsV1().Deployments(options.Namespace).List(ctx, metav1.ListOptions{})
+	count := 0
+	var fnErr error
+	err := listInChunks(ctx, logger, options, func(ctx context.Context, listOptions metav1.ListOptions, restarted bool) (string, error) {
+		deployments, err := c.clientset.AppsV1().Deployments(options.Namespace).List(ctx, listOptions)
+		if err != nil {
+			return "", err
+		}
+		if restarted {
+			count = 0
+		}
+		count += len(deployments.Items)
+		if fnErr = fn(deployments.Items, restarted); fnErr != nil {
+			return "", fnErr
+		}
+		return deployments.Continue, nil
+	})
+	if fnErr != nil {
+		return fnErr
+	}
 	if err != nil {
 		logger.Error().Err(err).Str("namespace", options.Namespace).Msg("failed to list deployments")
-		return nil, errors.NewConnectionError("failed to list deployments", err)
+		return errors.NewConnectionError("failed to list deployments", err)
 	}
 
 	logger.Info().
 		Str("namespace", options.Namespace).
-		Int("count", len(deployments.Items)).
+		Int("count", count).
 		Msg("deployments listed successfully")
 
-	return deployments, nil
+	return nil
 }
 
 // GetDeployment gets a specific deployment by name
@@ -95,3 +140,210 @@ func (c *Client) GetDeployment(ctx context.Context, namespace, name string) (*ap
 
 	return deployment, nil
 }
+
+// Annotations and labels managed by the deployment controller and kubectl
+const (
+	// RestartedAtAnnotation is bumped on the pod template to restart all pods,
+	// as kubectl rollout restart does
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+	// RevisionAnnotation holds the rollout revision of a ReplicaSet
+	RevisionAnnotation = "deployment.kubernetes.io/revision"
+	// podTemplateHashLabel is added to the pod template of ReplicaSets
+	podTemplateHashLabel = "pod-template-hash"
+)
+
+// ScaleDeployment sets the number of replicas of a deployment
+func (c *Client) ScaleDeployment(ctx context.Context, namespace, name string, replicas int32) (*appsv1.Deployment, error) {
+	if replicas < 0 {
+		return nil, errors.NewValidationError("replicas", "cannot be negative")
+	}
+	return c.updateDeployment(ctx, "scale-deployment", "scale", namespace, name, func(deployment *appsv1.Deployment) error {
+		deployment.Spec.Replicas = &replicas
+		return nil
+	})
+}
+
+// RestartDeployment replaces all pods of a deployment with a rollout, by
+// bumping the RestartedAtAnnotation of its pod template
+func (c *Client) RestartDeployment(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
+	restartedAt := time.Now().Format(time.RFC3339)
+	return c.updateDeployment(ctx, "restart-deployment", "restart", namespace, name, func(deployment *appsv1.Deployment) error {
+		if deployment.Spec.Paused {
+			return errors.NewValidationError("deployment", "cannot restart a paused deployment, resume it first")
+		}
+		if deployment.Spec.Template.Annotations == nil {
+			deployment.Spec.Template.Annotations = map[string]string{}
+		}
+		deployment.Spec.Template.Annotations[RestartedAtAnnotation] = restartedAt
+		return nil
+	})
+}
+
+// PauseDeployment pauses the rollouts of a deployment; changes to its pod
+// template are not rolled out until it is resumed. Pausing a paused deployment
+// is a no-op.
+func (c *Client) PauseDeployment(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
+	return c.updateDeployment(ctx, "pause-deployment", "pause", namespace, name, func(deployment *appsv1.Deployment) error {
+		deployment.Spec.Paused = true
+		return nil
+	})
+}
+
+// ResumeDeployment resumes the rollouts of a paused deployment. Resuming a
+// deployment that is not paused is a no-op.
+func (c *Client) ResumeDeployment(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
+	return c.updateDeployment(ctx, "resume-deployment", "resume", namespace, name, func(deployment *appsv1.Deployment) error {
+		deployment.Spec.Paused = false
+		return nil
+	})
+}
+
+// SetDeploymentImage sets container images of a deployment. images maps
+// container names, including init containers, to their new image; "*" sets the
+// image of all containers.
+func (c *Client) SetDeploymentImage(ctx context.Context, namespace, name string, images map[string]string) (*appsv1.Deployment, error) {
+	if len(images) == 0 {
+		return nil, errors.NewValidationError("images", "cannot be empty")
+	}
+	return c.updateDeployment(ctx, "set-image-deployment", "set image of", namespace, name, func(deployment *appsv1.Deployment) error {
+		spec := &deployment.Spec.Template.Spec
+		for container, image := range images {
+			found := false
+			for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+				for j := range containers {
+					if container == "*" || containers[j].Name == container {
+						containers[j].Image = image
+						found = true
+					}
+				}
+			}
+			if !found {
+				return errors.NewValidationError("images", fmt.Sprintf("deployment %s/%s has no container %q", namespace, name, container))
+			}
+		}
+		return nil
+	})
+}
+
+// RollbackDeployment rolls a deployment back to the pod template of the
+// ReplicaSet with the given revision, or of the previous revision if revision
+// is 0. Rolling back to the current template is a no-op.
+func (c *Client) RollbackDeployment(ctx context.Context, namespace, name string, revision int64) (*appsv1.Deployment, error) {
+	if revision < 0 {
+		return nil, errors.NewValidationError("revision", "cannot be negative")
+	}
+	return c.updateDeployment(ctx, "rollback-deployment", "roll back", namespace, name, func(deployment *appsv1.Deployment) error {
+		if deployment.Spec.Paused {
+			return errors.NewValidationError("deployment", "cannot roll back a paused deployment, resume it first")
+		}
+		replicaSet, err := c.deploymentRevision(ctx, deployment, revision)
+		if err != nil {
+			return err
+		}
+
+		template := replicaSet.Spec.Template.DeepCopy()
+		delete(template.Labels, podTemplateHashLabel)
+		deployment.Spec.Template = *template
+		return nil
+	})
+}
+
+// deploymentRevision returns the ReplicaSet of a deployment with the given
+// revision, or of the revision before the latest one if revision is 0
+func (c *Client) deploymentRevision(ctx context.Context, deployment *appsv1.Deployment, revision int64) (*appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, errors.NewValidationError("selector", err.Error())
+	}
+	replicaSets, err := c.clientset.AppsV1().ReplicaSets(deployment.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	// Revisions of the ReplicaSets owned by the deployment, latest first
+	var owned []*appsv1.ReplicaSet
+	revisions := map[*appsv1.ReplicaSet]int64{}
+	for j := range replicaSets.Items {
+		replicaSet := &replicaSets.Items[j]
+		owner := metav1.GetControllerOf(replicaSet)
+		if owner == nil || owner.UID != deployment.UID {
+			continue
+		}
+		rev, err := strconv.ParseInt(replicaSet.Annotations[RevisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		owned = append(owned, replicaSet)
+		revisions[replicaSet] = rev
+	}
+	sort.Slice(owned, func(a, b int) bool { return revisions[owned[a]] > revisions[owned[b]] })
+
+	switch {
+	case revision == 0 && len(owned) < 2:
+		return nil, errors.NewValidationError("revision", fmt.Sprintf("deployment %s/%s has no previous revision", deployment.Namespace, deployment.Name))
+	case revision == 0:
+		return owned[1], nil
+	}
+	for _, replicaSet := range owned {
+		if revisions[replicaSet] == revision {
+			return replicaSet, nil
+		}
+	}
+	return nil, errors.NewValidationError("revision", fmt.Sprintf("deployment %s/%s has no revision %d", deployment.Namespace, deployment.Name, revision))
+}
+
+// updateDeployment applies mutate to the latest version of a deployment and
+// updates it, retrying on conflicts with concurrent writers. The update is
+// skipped if mutate leaves the deployment unchanged.
+func (c *Client) updateDeployment(ctx context.Context, operation, action, namespace, name string, mutate func(deployment *appsv1.Deployment) error) (*appsv1.Deployment, error) {
+	logger := c.logger.With().Str("operation", operation).Logger()
+
+	// Basic validation
+	if namespace == "" {
+		return nil, errors.NewValidationError("namespace", "cannot be empty")
+	}
+	if name == "" {
+		return nil, errors.NewValidationError("name", "cannot be empty")
+	}
+
+	logger.Debug().
+		Str("namespace", namespace).
+		Str("name", name).
+		Msg("updating deployment")
+
+	var updated *appsv1.Deployment
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		original := deployment.DeepCopy()
+		if err := mutate(deployment); err != nil {
+			return err
+		}
+		if equality.Semantic.DeepEqual(original.Spec, deployment.Spec) {
+			updated = deployment
+			return nil
+		}
+		updated, err = c.clientset.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		logger.Error().Err(err).
+			Str("namespace", namespace).
+			Str("name", name).
+			Msg("failed to update deployment")
+		if _, ok := err.(*errors.ValidationError); ok {
+			return nil, err
+		}
+		return nil, errors.NewConnectionError("failed to "+action+" deployment", err)
+	}
+
+	logger.Info().
+		Str("namespace", namespace).
+		Str("name", name).
+		Int64("generation", updated.Generation).
+		Msg("deployment updated successfully")
+
+	return updated, nil
+}
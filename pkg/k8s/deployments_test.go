@@ -0,0 +1,156 @@
+package k8s
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/rs/zerolog"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
+)
+
+func newTestDeployment(image string) *appsv1.Deployment {
+	replicas := int32(1)
+	labels := map[string]string{"app": "web"}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "uid-web"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "migrate", Image: image}},
+					Containers:     []corev1.Container{{Name: "app", Image: image}, {Name: "proxy", Image: "envoy:1.28"}},
+				},
+			},
+		},
+	}
+}
+
+// newTestReplicaSet returns a ReplicaSet of deployment with the given revision and image
+func newTestReplicaSet(deployment *appsv1.Deployment, revision int, image string) *appsv1.ReplicaSet {
+	template := newTestDeployment(image).Spec.Template
+	template.Labels = map[string]string{"app": "web", podTemplateHashLabel: "hash-" + strconv.Itoa(revision)}
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-" + strconv.Itoa(revision),
+			Namespace:       "default",
+			Labels:          template.Labels,
+			Annotations:     map[string]string{RevisionAnnotation: strconv.Itoa(revision)},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(deployment, appsv1.SchemeGroupVersion.WithKind("Deployment"))},
+		},
+		Spec: appsv1.ReplicaSetSpec{Selector: deployment.Spec.Selector, Template: template},
+	}
+}
+
+func newFakeDeploymentClient(objects ...runtime.Object) (*Client, *kubefake.Clientset) {
+	clientset := kubefake.NewSimpleClientset(objects...)
+	return &Client{clientset: clientset, logger: zerolog.Nop()}, clientset
+}
+
+func TestDeploymentLifecycleOperations(t *testing.T) {
+	client, _ := newFakeDeploymentClient(newTestDeployment("nginx:1.24"))
+	ctx := context.Background()
+
+	deployment, err := client.ScaleDeployment(ctx, "default", "web", 3)
+	if err != nil || *deployment.Spec.Replicas != 3 {
+		t.Errorf("expected 3 replicas, got %v (%v)", deployment, err)
+	}
+
+	deployment, err = client.RestartDeployment(ctx, "default", "web")
+	if err != nil || deployment.Spec.Template.Annotations[RestartedAtAnnotation] == "" {
+		t.Errorf("expected the restartedAt annotation, got %v (%v)", deployment, err)
+	}
+
+	deployment, err = client.PauseDeployment(ctx, "default", "web")
+	if err != nil || !deployment.Spec.Paused {
+		t.Errorf("expected a paused deployment, got %v (%v)", deployment, err)
+	}
+	if _, err := client.RestartDeployment(ctx, "default", "web"); err == nil {
+		t.Errorf("expected restarting a paused deployment to fail")
+	}
+	deployment, err = client.ResumeDeployment(ctx, "default", "web")
+	if err != nil || deployment.Spec.Paused {
+		t.Errorf("expected a resumed deployment, got %v (%v)", deployment, err)
+	}
+
+	deployment, err = client.SetDeploymentImage(ctx, "default", "web", map[string]string{"app": "nginx:1.25", "migrate": "nginx:1.25"})
+	if err != nil {
+		t.Fatalf("failed to set image: %v", err)
+	}
+	spec := deployment.Spec.Template.Spec
+	if spec.Containers[0].Image != "nginx:1.25" || spec.InitContainers[0].Image != "nginx:1.25" || spec.Containers[1].Image != "envoy:1.28" {
+		t.Errorf("expected only the app and migrate images to change, got %+v", spec)
+	}
+	deployment, err = client.SetDeploymentImage(ctx, "default", "web", map[string]string{"*": "busybox"})
+	if err != nil || deployment.Spec.Template.Spec.Containers[1].Image != "busybox" {
+		t.Errorf("expected all images to change, got %v (%v)", deployment, err)
+	}
+
+	_, err = client.SetDeploymentImage(ctx, "default", "web", map[string]string{"sidecar": "busybox"})
+	if _, ok := err.(*errors.ValidationError); !ok {
+		t.Errorf("expected a validation error for an unknown container, got %v", err)
+	}
+	if _, err := client.ScaleDeployment(ctx, "default", "missing", 1); !apierrors.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestRollbackDeployment(t *testing.T) {
+	deployment := newTestDeployment("nginx:1.25")
+	client, _ := newFakeDeploymentClient(deployment,
+		newTestReplicaSet(deployment, 1, "nginx:1.23"),
+		newTestReplicaSet(deployment, 2, "nginx:1.24"),
+		newTestReplicaSet(deployment, 3, "nginx:1.25"),
+	)
+	ctx := context.Background()
+
+	rolledBack, err := client.RollbackDeployment(ctx, "default", "web", 0)
+	if err != nil {
+		t.Fatalf("failed to roll back: %v", err)
+	}
+	if image := rolledBack.Spec.Template.Spec.Containers[0].Image; image != "nginx:1.24" {
+		t.Errorf("expected the previous revision's image nginx:1.24, got %s", image)
+	}
+	if _, ok := rolledBack.Spec.Template.Labels[podTemplateHashLabel]; ok {
+		t.Errorf("expected the pod-template-hash label to be dropped")
+	}
+
+	rolledBack, err = client.RollbackDeployment(ctx, "default", "web", 1)
+	if err != nil || rolledBack.Spec.Template.Spec.Containers[0].Image != "nginx:1.23" {
+		t.Errorf("expected revision 1's image nginx:1.23, got %v (%v)", rolledBack, err)
+	}
+	if _, err := client.RollbackDeployment(ctx, "default", "web", 7); err == nil {
+		t.Errorf("expected an error for an unknown revision")
+	}
+}
+
+func TestUpdateDeploymentRetriesConflicts(t *testing.T) {
+	client, clientset := newFakeDeploymentClient(newTestDeployment("nginx:1.24"))
+	conflicts := 0
+	clientset.PrependReactor("update", "deployments", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if conflicts < 2 {
+			conflicts++
+			return true, nil, apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "web", nil)
+		}
+		return false, nil, nil
+	})
+
+	deployment, err := client.ScaleDeployment(context.Background(), "default", "web", 5)
+	if err != nil || *deployment.Spec.Replicas != 5 {
+		t.Errorf("expected the scale to succeed after conflicts, got %v (%v)", deployment, err)
+	}
+	if conflicts != 2 {
+		t.Errorf("expected 2 conflicts, got %d", conflicts)
+	}
+}
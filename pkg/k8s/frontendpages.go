@@ -2,10 +2,17 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/dynamic"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
 	"github.com/thegostev/go-kubernetes-controllers/internal/types"
@@ -15,12 +22,29 @@ import (
 // ListFrontendPages lists frontend pages in the specified namespace
 // (follows exact same pattern as ListDeployments)
 func (c *Client) ListFrontendPages(ctx context.Context, options *types.ListOptions) (*v1alpha1.FrontendPageList, error) {
+	frontendPageList := &v1alpha1.FrontendPageList{}
+	err := c.ListFrontendPagesInChunks(ctx, options, func(chunk []v1alpha1.FrontendPage, restarted bool) error {
+		if restarted {
+			frontendPageList.Items = nil
+		}
+		frontendPageList.Items = append(frontendPageList.Items, chunk...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return frontendPageList, nil
+}
+
+// ListFrontendPagesInChunks lists frontend pages in the specified namespace in
+// chunks of options.ChunkSize (follows exact same pattern as ListDeploymentsInChunks)
+func (c *Client) ListFrontendPagesInChunks(ctx context.Context, options *types.ListOptions, fn func(pages []v1alpha1.FrontendPage, restarted bool) error) error {
 	logger := c.logger.With().Str("operation", "list-frontendpages").Logger()
 
 	// Validate options
 	if err := options.Validate(); err != nil {
 		logger.Error().Err(err).Msg("invalid list options")
-		return nil, errors.NewValidationError("list options", err.Error())
+		return errors.NewValidationError("list options", err.Error())
 	}
 
 	// Set defaults
@@ -29,47 +53,65 @@ func (c *Client) ListFrontendPages(ctx context.Context, options *types.ListOptio
 	logger.Debug().
 		Str("namespace", options.Namespace).
 		Dur("timeout", options.Timeout).
+		Str("labelSelector", options.LabelSelector).
+		Str("fieldSelector", options.FieldSelector).
+		Int64("chunkSize", options.ChunkSize).
 		Msg("listing frontend pages")
 
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
-	defer cancel()
-
 	// Check if context is already cancelled
 	select {
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return ctx.Err()
 	default:
 	}
 
-	// Use dynamic client for CRD
-	dynamicClient, err := dynamic.NewForConfig(c.restConfig)
-	if err != nil {
-		logger.Error().Err(err).Msg("failed to create dynamic client")
-		return nil, errors.NewConnectionError("failed to create dynamic client", err)
-	}
-
 	// List frontend pages
-	frontendPageGVR := v1alpha1.GroupVersion.WithResource("frontendpages")
-	unstructuredList, err := dynamicClient.Resource(frontendPageGVR).Namespace(options.Namespace).List(ctx, metav1.ListOptions{})
+	count := 0
+	var fnErr error
+	err := listInChunks(ctx, logger, options, func(ctx context.Context, listOptions metav1.ListOptions, restarted bool) (string, error) {
+		frontendPageList := &v1alpha1.FrontendPageList{}
+		if err := c.ctrlClient.List(ctx, frontendPageList, ctrlListOptions(options.Namespace, listOptions)); err != nil {
+			return "", err
+		}
+		if restarted {
+			count = 0
+		}
+		count += len(frontendPageList.Items)
+		if fnErr = fn(frontendPageList.Items, restarted); fnErr != nil {
+			return "", fnErr
+		}
+		return frontendPageList.Continue, nil
+	})
+	if fnErr != nil {
+		return fnErr
+	}
 	if err != nil {
 		logger.Error().Err(err).Str("namespace", options.Namespace).Msg("failed to list frontend pages")
-		return nil, errors.NewConnectionError("failed to list frontend pages", err)
-	}
-
-	// Convert to typed list
-	frontendPageList := &v1alpha1.FrontendPageList{}
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredList.Object, frontendPageList); err != nil {
-		logger.Error().Err(err).Msg("failed to convert unstructured list")
-		return nil, errors.NewConnectionError("failed to convert unstructured list", err)
+		return errors.NewConnectionError("failed to list frontend pages", err)
 	}
 
 	logger.Info().
 		Str("namespace", options.Namespace).
-		Int("count", len(frontendPageList.Items)).
+		Int("count", count).
 		Msg("frontend pages listed successfully")
 
-	return frontendPageList, nil
+	return nil
+}
+
+// ctrlListOptions converts validated list options for the typed client
+func ctrlListOptions(namespace string, listOptions metav1.ListOptions) *ctrlclient.ListOptions {
+	options := &ctrlclient.ListOptions{
+		Namespace: namespace,
+		Limit:     listOptions.Limit,
+		Continue:  listOptions.Continue,
+	}
+	if listOptions.LabelSelector != "" {
+		options.LabelSelector, _ = labels.Parse(listOptions.LabelSelector)
+	}
+	if listOptions.FieldSelector != "" {
+		options.FieldSelector, _ = fields.ParseSelector(listOptions.FieldSelector)
+	}
+	return options
 }
 
 // GetFrontendPage gets a specific frontend page by name
@@ -96,17 +138,9 @@ func (c *Client) GetFrontendPage(ctx context.Context, namespace, name string) (*
 	default:
 	}
 
-	// Use dynamic client for CRD
-	dynamicClient, err := dynamic.NewForConfig(c.restConfig)
-	if err != nil {
-		logger.Error().Err(err).Msg("failed to create dynamic client")
-		return nil, errors.NewConnectionError("failed to create dynamic client", err)
-	}
-
 	// Get frontend page
-	frontendPageGVR := v1alpha1.GroupVersion.WithResource("frontendpages")
-	unstructuredObj, err := dynamicClient.Resource(frontendPageGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
-	if err != nil {
+	frontendPage := &v1alpha1.FrontendPage{}
+	if err := c.ctrlClient.Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: name}, frontendPage); err != nil {
 		logger.Error().Err(err).
 			Str("namespace", namespace).
 			Str("name", name).
@@ -114,13 +148,6 @@ func (c *Client) GetFrontendPage(ctx context.Context, namespace, name string) (*
 		return nil, errors.NewConnectionError("failed to get frontend page", err)
 	}
 
-	// Convert to typed object
-	frontendPage := &v1alpha1.FrontendPage{}
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObj.Object, frontendPage); err != nil {
-		logger.Error().Err(err).Msg("failed to convert unstructured object")
-		return nil, errors.NewConnectionError("failed to convert unstructured object", err)
-	}
-
 	logger.Debug().
 		Str("namespace", namespace).
 		Str("name", name).
@@ -128,3 +155,179 @@ func (c *Client) GetFrontendPage(ctx context.Context, namespace, name string) (*
 
 	return frontendPage, nil
 }
+
+// CreateFrontendPage creates a frontend page; page is updated with the
+// object returned by the API server
+func (c *Client) CreateFrontendPage(ctx context.Context, page *v1alpha1.FrontendPage) error {
+	return c.writeFrontendPage(ctx, "create-frontendpage", "create", page, func(ctx context.Context) error {
+		return c.ctrlClient.Create(ctx, page)
+	})
+}
+
+// UpdateFrontendPage replaces the spec and metadata of a frontend page. The
+// update fails with a conflict if page is not based on the latest resourceVersion.
+func (c *Client) UpdateFrontendPage(ctx context.Context, page *v1alpha1.FrontendPage) error {
+	return c.writeFrontendPage(ctx, "update-frontendpage", "update", page, func(ctx context.Context) error {
+		return c.ctrlClient.Update(ctx, page)
+	})
+}
+
+// UpdateFrontendPageStatus replaces the status of a frontend page through the
+// status subresource
+func (c *Client) UpdateFrontendPageStatus(ctx context.Context, page *v1alpha1.FrontendPage) error {
+	return c.writeFrontendPage(ctx, "update-frontendpage-status", "update status of", page, func(ctx context.Context) error {
+		return c.ctrlClient.Status().Update(ctx, page)
+	})
+}
+
+// PatchFrontendPage patches a frontend page with a JSON or merge patch and
+// returns the patched page; custom resources do not support strategic merge patches
+func (c *Client) PatchFrontendPage(ctx context.Context, namespace, name string, patchType apitypes.PatchType, data []byte) (*v1alpha1.FrontendPage, error) {
+	page := &v1alpha1.FrontendPage{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	err := c.writeFrontendPage(ctx, "patch-frontendpage", "patch", page, func(ctx context.Context) error {
+		return c.ctrlClient.Patch(ctx, page, ctrlclient.RawPatch(patchType, data))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// DeleteFrontendPage deletes a frontend page. The page and its children stay
+// until the controller has run its cleanup hooks and removed the finalizer.
+func (c *Client) DeleteFrontendPage(ctx context.Context, namespace, name string) error {
+	page := &v1alpha1.FrontendPage{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	return c.writeFrontendPage(ctx, "delete-frontendpage", "delete", page, func(ctx context.Context) error {
+		return c.ctrlClient.Delete(ctx, page)
+	})
+}
+
+// ApplyFrontendPage creates or updates a frontend page with server-side apply
+// as fieldManager and returns the page stored by the API server. page holds
+// the fields fieldManager owns; fields it owned before and left out are
+// removed. With force, fields owned by other managers are taken over
+// instead of failing with a conflict.
+func (c *Client) ApplyFrontendPage(ctx context.Context, page *v1alpha1.FrontendPage, fieldManager string, force bool) (*v1alpha1.FrontendPage, error) {
+	if fieldManager == "" {
+		return nil, errors.NewValidationError("fieldManager", "cannot be empty")
+	}
+
+	// Apply requires apiVersion and kind and rejects managed fields
+	applied := page.DeepCopy()
+	applied.SetGroupVersionKind(v1alpha1.GroupVersion.WithKind("FrontendPage"))
+	applied.ManagedFields = nil
+
+	options := []ctrlclient.PatchOption{ctrlclient.FieldOwner(fieldManager)}
+	if force {
+		options = append(options, ctrlclient.ForceOwnership)
+	}
+	err := c.writeFrontendPage(ctx, "apply-frontendpage", "apply", applied, func(ctx context.Context) error {
+		return c.ctrlClient.Patch(ctx, applied, ctrlclient.Apply, options...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// WaitForFrontendPageDeletion blocks until a frontend page is gone, that is
+// until the controller has run its cleanup hooks and removed the finalizer.
+// The page is followed with a watch. It returns a WatchError if ctx is done first.
+func (c *Client) WaitForFrontendPageDeletion(ctx context.Context, namespace, name string) error {
+	logger := c.logger.With().Str("operation", "wait-for-frontendpage-deletion").Logger()
+
+	// Basic validation
+	if namespace == "" {
+		return errors.NewValidationError("namespace", "cannot be empty")
+	}
+	if name == "" {
+		return errors.NewValidationError("name", "cannot be empty")
+	}
+
+	logger.Debug().
+		Str("namespace", namespace).
+		Str("name", name).
+		Msg("waiting for frontend page deletion")
+
+	// Watch the page only
+	listOptions := func(options metav1.ListOptions) *ctrlclient.ListOptions {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		return ctrlListOptions(namespace, options)
+	}
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			frontendPageList := &v1alpha1.FrontendPageList{}
+			err := c.ctrlClient.List(ctx, frontendPageList, listOptions(options))
+			return frontendPageList, err
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return c.ctrlClient.Watch(ctx, &v1alpha1.FrontendPageList{}, listOptions(options))
+		},
+	}
+
+	// Done right away if the page is already gone
+	gone := func(store cache.Store) (bool, error) {
+		_, found, err := store.Get(&metav1.ObjectMeta{Namespace: namespace, Name: name})
+		return err == nil && !found, err
+	}
+
+	_, err := watchtools.UntilWithSync(ctx, listWatch, &v1alpha1.FrontendPage{}, gone, func(event watch.Event) (bool, error) {
+		page, ok := event.Object.(*v1alpha1.FrontendPage)
+		return ok && page.Name == name && event.Type == watch.Deleted, nil
+	})
+	if err != nil {
+		logger.Error().Err(err).
+			Str("namespace", namespace).
+			Str("name", name).
+			Msg("frontend page was not deleted")
+		return errors.NewWatchError(fmt.Sprintf("stopped waiting for deletion of frontend page %s/%s", namespace, name), err)
+	}
+
+	logger.Info().
+		Str("namespace", namespace).
+		Str("name", name).
+		Msg("frontend page deleted")
+
+	return nil
+}
+
+// writeFrontendPage validates the page key and runs a write operation with
+// logging and error wrapping shared by all writes
+func (c *Client) writeFrontendPage(ctx context.Context, operation, action string, page *v1alpha1.FrontendPage, write func(ctx context.Context) error) error {
+	logger := c.logger.With().Str("operation", operation).Logger()
+
+	// Basic validation
+	if page.Namespace == "" {
+		return errors.NewValidationError("namespace", "cannot be empty")
+	}
+	if page.Name == "" {
+		return errors.NewValidationError("name", "cannot be empty")
+	}
+
+	logger.Debug().
+		Str("namespace", page.Namespace).
+		Str("name", page.Name).
+		Msgf("%s frontend page", action)
+
+	// Check if context is cancelled
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if err := write(ctx); err != nil {
+		logger.Error().Err(err).
+			Str("namespace", page.Namespace).
+			Str("name", page.Name).
+			Msg("failed to write frontend page")
+		return errors.NewConnectionError("failed to "+action+" frontend page", err)
+	}
+
+	logger.Info().
+		Str("namespace", page.Namespace).
+		Str("name", page.Name).
+		Msgf("%s frontend page succeeded", action)
+
+	return nil
+}
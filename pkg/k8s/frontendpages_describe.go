@@ -0,0 +1,91 @@
+package k8s
+
+import (
+	"context"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
+)
+
+// nameLabel is set by the controller to "frontendpage" on the children of
+// every frontend page; it narrows the children lookup before the owner check
+const nameLabel = "app.kubernetes.io/name"
+
+// FrontendPageDescription is a frontend page with its Events and the
+// children the controller created for it
+type FrontendPageDescription struct {
+	Page *v1alpha1.FrontendPage
+	// Events about the page, oldest first
+	Events []corev1.Event
+	// Children are the ConfigMaps, Deployments and Services controlled by the page
+	Children []ctrlclient.Object
+}
+
+// DescribeFrontendPage gets a frontend page together with its Events and children
+func (c *Client) DescribeFrontendPage(ctx context.Context, namespace, name string) (*FrontendPageDescription, error) {
+	logger := c.logger.With().Str("operation", "describe-frontendpage").Logger()
+
+	page, err := c.GetFrontendPage(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	events := &corev1.EventList{}
+	if err := c.ctrlClient.List(ctx, events, &ctrlclient.ListOptions{
+		Namespace:     namespace,
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", string(page.UID)),
+	}); err != nil {
+		logger.Error().Err(err).Str("namespace", namespace).Str("name", name).Msg("failed to list events")
+		return nil, errors.NewConnectionError("failed to list events of frontend page", err)
+	}
+	sort.SliceStable(events.Items, func(i, j int) bool {
+		return EventTime(&events.Items[i]).Time.Before(EventTime(&events.Items[j]).Time)
+	})
+
+	// Children of each kind, in the order the controller creates them
+	var children []ctrlclient.Object
+	for _, list := range []ctrlclient.ObjectList{&corev1.ConfigMapList{}, &appsv1.DeploymentList{}, &corev1.ServiceList{}} {
+		if err := c.ctrlClient.List(ctx, list, ctrlclient.InNamespace(namespace), ctrlclient.MatchingLabels{nameLabel: "frontendpage"}); err != nil {
+			logger.Error().Err(err).Str("namespace", namespace).Str("name", name).Msg("failed to list children")
+			return nil, errors.NewConnectionError("failed to list children of frontend page", err)
+		}
+		objects, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, errors.NewConnectionError("failed to list children of frontend page", err)
+		}
+		for _, object := range objects {
+			if child := object.(ctrlclient.Object); metav1.IsControlledBy(child, page) {
+				children = append(children, child)
+			}
+		}
+	}
+
+	logger.Debug().
+		Str("namespace", namespace).
+		Str("name", name).
+		Int("events", len(events.Items)).
+		Int("children", len(children)).
+		Msg("frontend page described successfully")
+
+	return &FrontendPageDescription{Page: page, Events: events.Items, Children: children}, nil
+}
+
+// EventTime returns when an event was last seen; events.k8s.io/v1 Events
+// only set EventTime
+func EventTime(event *corev1.Event) metav1.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp
+	}
+	if !event.EventTime.IsZero() {
+		return metav1.NewTime(event.EventTime.Time)
+	}
+	return event.FirstTimestamp
+}
@@ -0,0 +1,215 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+)
+
+func newFakeFrontendPageClient(objects ...ctrlclient.Object) *Client {
+	ctrlClient := fake.NewClientBuilder().
+		WithScheme(NewScheme()).
+		WithStatusSubresource(&v1alpha1.FrontendPage{}).
+		WithObjects(objects...).
+		// The fake client evaluates field selectors through indexes
+		WithIndex(&v1alpha1.FrontendPage{}, "metadata.name", func(object ctrlclient.Object) []string {
+			return []string{object.GetName()}
+		}).
+		WithIndex(&corev1.Event{}, "involvedObject.uid", func(object ctrlclient.Object) []string {
+			return []string{string(object.(*corev1.Event).InvolvedObject.UID)}
+		}).
+		Build()
+	return &Client{ctrlClient: ctrlClient, logger: zerolog.Nop()}
+}
+
+func TestFrontendPageLifecycle(t *testing.T) {
+	client := newFakeFrontendPageClient()
+	ctx := context.Background()
+
+	page := &v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: "default", Labels: map[string]string{"team": "web"}},
+		Spec:       v1alpha1.FrontendPageSpec{Title: "Home", Template: "landing"},
+	}
+	if err := client.CreateFrontendPage(ctx, page); err != nil {
+		t.Fatalf("failed to create page: %v", err)
+	}
+	if page.ResourceVersion == "" {
+		t.Errorf("expected the created page to be updated from the server")
+	}
+
+	page.Spec.Title = "Welcome"
+	if err := client.UpdateFrontendPage(ctx, page); err != nil {
+		t.Fatalf("failed to update page: %v", err)
+	}
+	page.Status.Phase = "Ready"
+	if err := client.UpdateFrontendPageStatus(ctx, page); err != nil {
+		t.Fatalf("failed to update page status: %v", err)
+	}
+
+	patched, err := client.PatchFrontendPage(ctx, "default", "home", apitypes.MergePatchType, []byte(`{"spec":{"template":"dashboard"}}`))
+	if err != nil {
+		t.Fatalf("failed to patch page: %v", err)
+	}
+	if patched.Spec.Title != "Welcome" || patched.Spec.Template != "dashboard" || patched.Status.Phase != "Ready" {
+		t.Errorf("unexpected patched page %+v", patched)
+	}
+
+	got, err := client.GetFrontendPage(ctx, "default", "home")
+	if err != nil || got.Spec.Template != "dashboard" {
+		t.Fatalf("expected the patched page, got %+v (%v)", got, err)
+	}
+	pages, err := client.ListFrontendPages(ctx, &types.ListOptions{Namespace: "default", Timeout: time.Second, LabelSelector: "team=web"})
+	if err != nil || len(pages.Items) != 1 {
+		t.Errorf("expected one page, got %v (%v)", pages, err)
+	}
+
+	if err := client.DeleteFrontendPage(ctx, "default", "home"); err != nil {
+		t.Fatalf("failed to delete page: %v", err)
+	}
+	if _, err := client.GetFrontendPage(ctx, "default", "home"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected a not found error after delete, got %v", err)
+	}
+}
+
+func TestFrontendPageUpdateConflicts(t *testing.T) {
+	client := newFakeFrontendPageClient()
+	ctx := context.Background()
+
+	page := &v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: "default"},
+		Spec:       v1alpha1.FrontendPageSpec{Title: "Home", Template: "landing"},
+	}
+	if err := client.CreateFrontendPage(ctx, page); err != nil {
+		t.Fatalf("failed to create page: %v", err)
+	}
+	stale := page.DeepCopy()
+	page.Spec.Title = "Welcome"
+	if err := client.UpdateFrontendPage(ctx, page); err != nil {
+		t.Fatalf("failed to update page: %v", err)
+	}
+
+	stale.Spec.Title = "Stale"
+	if err := client.UpdateFrontendPage(ctx, stale); !apierrors.IsConflict(err) {
+		t.Errorf("expected a conflict for a stale update, got %v", err)
+	}
+	if err := client.CreateFrontendPage(ctx, &v1alpha1.FrontendPage{}); err == nil {
+		t.Errorf("expected a validation error for a page without name")
+	}
+}
+
+func TestApplyFrontendPage(t *testing.T) {
+	client := newFakeFrontendPageClient(&v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: "default"},
+		Spec:       v1alpha1.FrontendPageSpec{Title: "Home", Template: "landing"},
+	})
+	ctx := context.Background()
+
+	applied, err := client.ApplyFrontendPage(ctx, &v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: "default"},
+		Spec:       v1alpha1.FrontendPageSpec{Title: "Welcome", Template: "landing", Theme: "dark"},
+	}, "ci", true)
+	if err != nil {
+		t.Fatalf("failed to apply page: %v", err)
+	}
+	if applied.Spec.Title != "Welcome" || applied.Spec.Theme != "dark" {
+		t.Errorf("expected the applied spec, got %+v", applied.Spec)
+	}
+
+	if _, err := client.ApplyFrontendPage(ctx, applied, "", false); err == nil {
+		t.Errorf("expected a validation error without field manager")
+	}
+}
+
+func TestWaitForFrontendPageDeletion(t *testing.T) {
+	page := &v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: "default", Finalizers: []string{"frontend.thegostev.com/finalizer"}},
+		Spec:       v1alpha1.FrontendPageSpec{Title: "Home", Template: "landing"},
+	}
+	client := newFakeFrontendPageClient(page)
+	ctx := context.Background()
+
+	// The finalizer keeps the page until the controller removes it
+	if err := client.DeleteFrontendPage(ctx, "default", "home"); err != nil {
+		t.Fatalf("failed to delete page: %v", err)
+	}
+	shortCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	if err := client.WaitForFrontendPageDeletion(shortCtx, "default", "home"); err == nil {
+		t.Fatalf("expected the wait to time out while the finalizer is set")
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.WaitForFrontendPageDeletion(ctx, "default", "home")
+	}()
+	_, err := client.PatchFrontendPage(ctx, "default", "home", apitypes.MergePatchType, []byte(`{"metadata":{"finalizers":null}}`))
+	if err != nil {
+		t.Fatalf("failed to remove finalizer: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected the wait to end with the deletion, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for WaitForFrontendPageDeletion to return")
+	}
+
+	// A page that is already gone needs no wait
+	if err := client.WaitForFrontendPageDeletion(ctx, "default", "home"); err != nil {
+		t.Errorf("expected no wait for a missing page, got %v", err)
+	}
+}
+
+func TestDescribeFrontendPage(t *testing.T) {
+	page := &v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: "default", UID: "page-uid"},
+		Spec:       v1alpha1.FrontendPageSpec{Title: "Home", Template: "landing"},
+	}
+	controlled := []metav1.OwnerReference{*metav1.NewControllerRef(page, v1alpha1.GroupVersion.WithKind("FrontendPage"))}
+	labels := map[string]string{nameLabel: "frontendpage"}
+	now := time.Now()
+	client := newFakeFrontendPageClient(page,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "frontendpage-home", Namespace: "default", Labels: labels, OwnerReferences: controlled}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "frontendpage-home", Namespace: "default", Labels: labels, OwnerReferences: controlled}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "frontendpage-home", Namespace: "default", Labels: labels, OwnerReferences: controlled}},
+		// Same labels but not controlled by the page
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: "default", Labels: labels}},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "home.2", Namespace: "default"}, Reason: "Second",
+			InvolvedObject: corev1.ObjectReference{UID: "page-uid"}, LastTimestamp: metav1.NewTime(now)},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "home.1", Namespace: "default"}, Reason: "First",
+			InvolvedObject: corev1.ObjectReference{UID: "page-uid"}, LastTimestamp: metav1.NewTime(now.Add(-time.Minute))},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"}, Reason: "Other",
+			InvolvedObject: corev1.ObjectReference{UID: "other-uid"}},
+	)
+
+	description, err := client.DescribeFrontendPage(context.Background(), "default", "home")
+	if err != nil {
+		t.Fatalf("failed to describe page: %v", err)
+	}
+	if description.Page.Spec.Title != "Home" {
+		t.Errorf("expected the page, got %+v", description.Page)
+	}
+	if len(description.Events) != 2 || description.Events[0].Reason != "First" || description.Events[1].Reason != "Second" {
+		t.Errorf("expected the page events oldest first, got %+v", description.Events)
+	}
+	if children := description.Children; len(children) != 3 {
+		t.Errorf("expected the three controlled children, got %d", len(children))
+	} else if _, ok := children[0].(*corev1.ConfigMap); !ok {
+		t.Errorf("expected the ConfigMap first, got %T", children[0])
+	} else if service, ok := children[2].(*corev1.Service); !ok || service.Name != "frontendpage-home" {
+		t.Errorf("expected the controlled Service last, got %T %s", children[2], children[2].GetName())
+	}
+}
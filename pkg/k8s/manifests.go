@@ -0,0 +1,59 @@
+package k8s
+
+import (
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/api/v1beta1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
+)
+
+// DecodeFrontendPages decodes the frontend pages of a YAML or JSON manifest;
+// YAML manifests may hold several documents separated by "---". Every
+// document must be a v1alpha1 or v1beta1 FrontendPage. v1beta1 pages are
+// converted with ConvertFrom, which keeps layout, component order and the full
+// theme in an annotation the conversion webhook restores them from.
+func DecodeFrontendPages(r io.Reader) ([]*v1alpha1.FrontendPage, error) {
+	gvk := v1alpha1.GroupVersion.WithKind("FrontendPage")
+	hubGVK := v1beta1.GroupVersion.WithKind("FrontendPage")
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+
+	var pages []*v1alpha1.FrontendPage
+	for document := 1; ; document++ {
+		object := &unstructured.Unstructured{}
+		if err := decoder.Decode(&object.Object); err == io.EOF {
+			return pages, nil
+		} else if err != nil {
+			return nil, errors.NewValidationError("manifest", fmt.Sprintf("document %d: %v", document, err))
+		}
+		// Skip empty documents
+		if len(object.Object) == 0 {
+			continue
+		}
+
+		page := &v1alpha1.FrontendPage{}
+		switch object.GroupVersionKind() {
+		case gvk:
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(object.Object, page, true); err != nil {
+				return nil, errors.NewValidationError("manifest", fmt.Sprintf("document %d: %v", document, err))
+			}
+		case hubGVK:
+			hub := &v1beta1.FrontendPage{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(object.Object, hub, true); err != nil {
+				return nil, errors.NewValidationError("manifest", fmt.Sprintf("document %d: %v", document, err))
+			}
+			if err := page.ConvertFrom(hub); err != nil {
+				return nil, errors.NewValidationError("manifest", fmt.Sprintf("document %d: %v", document, err))
+			}
+		default:
+			return nil, errors.NewValidationError("manifest", fmt.Sprintf("document %d: expected a %s FrontendPage, got %s %s",
+				document, gvk.Group, object.GetAPIVersion(), object.GetKind()))
+		}
+		pages = append(pages, page)
+	}
+}
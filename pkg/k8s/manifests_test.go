@@ -0,0 +1,105 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1beta1"
+)
+
+const v1alpha1Manifest = `apiVersion: frontend.thegostev.com/v1alpha1
+kind: FrontendPage
+metadata:
+  name: home
+spec:
+  title: Home
+  template: landing
+  components:
+  - name: hero
+    type: text
+    config:
+      text: Welcome
+---
+---
+{"apiVersion": "frontend.thegostev.com/v1alpha1", "kind": "FrontendPage", "metadata": {"name": "about", "namespace": "shop"}, "spec": {"title": "About", "template": "default"}}
+`
+
+const v1beta1Manifest = `apiVersion: frontend.thegostev.com/v1beta1
+kind: FrontendPage
+metadata:
+  name: dashboard
+spec:
+  title: Dashboard
+  template: dashboard
+  theme:
+    name: dark
+    primaryColor: "#ff6600"
+  layout:
+    type: grid
+    columns: 2
+  components:
+  - name: metrics
+    type: table
+    order: 2
+    table:
+      columns: [Name, Status]
+  - name: trend
+    type: chart
+    order: 1
+`
+
+func TestDecodeFrontendPages(t *testing.T) {
+	t.Run("v1alpha1", func(t *testing.T) {
+		pages, err := DecodeFrontendPages(strings.NewReader(v1alpha1Manifest))
+		if err != nil {
+			t.Fatalf("failed to decode manifest: %v", err)
+		}
+		if len(pages) != 2 || pages[0].Name != "home" || pages[1].Namespace != "shop" {
+			t.Fatalf("expected the two pages, got %+v", pages)
+		}
+		if components := pages[0].Spec.Components; len(components) != 1 || components[0].Config["text"] != "Welcome" {
+			t.Errorf("expected the hero component, got %+v", components)
+		}
+	})
+
+	t.Run("v1beta1", func(t *testing.T) {
+		pages, err := DecodeFrontendPages(strings.NewReader(v1beta1Manifest))
+		if err != nil {
+			t.Fatalf("failed to decode manifest: %v", err)
+		}
+		if len(pages) != 1 {
+			t.Fatalf("expected one page, got %d", len(pages))
+		}
+		page := pages[0]
+		if page.Spec.Theme != "dark" || fmt.Sprint(page.Spec.Components[0].Config["columns"]) != "[Name Status]" {
+			t.Errorf("expected the theme and typed table config, got %+v", page.Spec)
+		}
+
+		// Converting back, as the API server does on apply, restores the v1beta1-only fields
+		hub := &v1beta1.FrontendPage{}
+		if err := page.ConvertTo(hub); err != nil {
+			t.Fatalf("failed to convert page to v1beta1: %v", err)
+		}
+		if hub.Spec.Layout != (v1beta1.Layout{Type: v1beta1.LayoutGrid, Columns: 2}) || hub.Spec.Theme.PrimaryColor != "#ff6600" {
+			t.Errorf("expected layout and theme to survive, got %+v", hub.Spec)
+		}
+		if hub.Spec.Components[0].Order != 2 || hub.Spec.Components[1].Order != 1 || hub.Spec.Components[0].Table == nil {
+			t.Errorf("expected component order and typed config to survive, got %+v", hub.Spec.Components)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, invalid := range []string{
+			"apiVersion: frontend.thegostev.com/v1\nkind: FrontendPage\nmetadata:\n  name: home\n",
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: home\n",
+			"apiVersion: frontend.thegostev.com/v1alpha1\nkind: FrontendPage\nspec:\n  titel: Home\n",
+			"apiVersion: frontend.thegostev.com/v1beta1\nkind: FrontendPage\nspec:\n  theme: dark\n",
+			"apiVersion: [",
+		} {
+			if _, err := DecodeFrontendPages(strings.NewReader(invalid)); err == nil {
+				t.Errorf("expected %q to be rejected", invalid)
+			}
+		}
+	})
+}
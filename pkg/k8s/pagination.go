@@ -0,0 +1,62 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+)
+
+// maxListRestarts is how often a paginated list restarts after its continue
+// token expired before giving up
+const maxListRestarts = 3
+
+// chunkFunc lists one chunk with listOptions, hands its items on and returns the
+// continue token of the next chunk. restarted is set on the first chunk of a
+// list restarted after its continue token expired.
+type chunkFunc func(ctx context.Context, listOptions metav1.ListOptions, restarted bool) (string, error)
+
+// listInChunks lists in chunks of at most options.ChunkSize objects, each
+// request bounded by options.Timeout. The chunks of a list come from a single
+// snapshot of the cluster. When a continue token expires (410 Gone) before the
+// list completes, the list restarts from the first chunk on a fresh snapshot,
+// so consumers must discard the chunks received before a restarted one.
+func listInChunks(ctx context.Context, logger zerolog.Logger, options *types.ListOptions, listChunk chunkFunc) error {
+	continueToken := ""
+	restarts := 0
+	restarted := false
+	for {
+		chunkCtx, cancel := context.WithTimeout(ctx, options.Timeout)
+		next, err := listChunk(chunkCtx, metav1.ListOptions{
+			LabelSelector: options.LabelSelector,
+			FieldSelector: options.FieldSelector,
+			Limit:         options.ChunkSize,
+			Continue:      continueToken,
+		}, restarted)
+		cancel()
+
+		if err != nil && continueToken != "" && (apierrors.IsResourceExpired(err) || apierrors.IsGone(err)) {
+			if restarts == maxListRestarts {
+				return fmt.Errorf("continue token expired %d times, giving up: %w", restarts+1, err)
+			}
+			restarts++
+			logger.Warn().Err(err).Int("restart", restarts).Msg("continue token expired, restarting list")
+			continueToken = ""
+			restarted = true
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		restarted = false
+		if next == "" {
+			return nil
+		}
+		continueToken = next
+	}
+}
@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+)
+
+// newPagingServer serves count deployments in chunks of the requested limit,
+// with the continue token holding the offset of the next chunk. The first
+// request for the offset in expire fails with 410 Gone.
+func newPagingServer(t *testing.T, count, expire int) (*Client, *[]string) {
+	t.Helper()
+	var requests []string
+	expired := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("continue"))
+		requests = append(requests, fmt.Sprintf("limit=%d,continue=%s", limit, r.URL.Query().Get("continue")))
+		w.Header().Set("Content-Type", "application/json")
+
+		if offset == expire && !expired {
+			expired = true
+			w.WriteHeader(http.StatusGone)
+			_ = json.NewEncoder(w).Encode(metav1.Status{
+				TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+				Status:   metav1.StatusFailure,
+				Reason:   metav1.StatusReasonExpired,
+				Code:     http.StatusGone,
+				Message:  "The provided continue parameter is too old",
+			})
+			return
+		}
+
+		list := appsv1.DeploymentList{TypeMeta: metav1.TypeMeta{Kind: "DeploymentList", APIVersion: "apps/v1"}}
+		end := count
+		if limit > 0 && offset+limit < count {
+			end = offset + limit
+			list.Continue = strconv.Itoa(end)
+		}
+		for j := offset; j < end; j++ {
+			list.Items = append(list.Items, appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("web-%d", j), Namespace: "default"}})
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+	t.Cleanup(server.Close)
+
+	config := &rest.Config{Host: server.URL}
+	return &Client{clientset: kubernetes.NewForConfigOrDie(config), logger: zerolog.Nop(), restConfig: config}, &requests
+}
+
+func TestListDeploymentsInChunks(t *testing.T) {
+	client, requests := newPagingServer(t, 5, -1)
+
+	var chunks []int
+	err := client.ListDeploymentsInChunks(context.Background(), &types.ListOptions{Namespace: "default", Timeout: time.Second, ChunkSize: 2}, func(deployments []appsv1.Deployment, restarted bool) error {
+		chunks = append(chunks, len(deployments))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(chunks) != "[2 2 1]" {
+		t.Errorf("expected chunks of 2, 2 and 1 deployments, got %v", chunks)
+	}
+	if want := "[limit=2,continue= limit=2,continue=2 limit=2,continue=4]"; fmt.Sprint(*requests) != want {
+		t.Errorf("expected requests %s, got %v", want, *requests)
+	}
+}
+
+func TestListDeploymentsRestartsOnExpiredContinueToken(t *testing.T) {
+	client, _ := newPagingServer(t, 5, 4)
+
+	var restarts int
+	err := client.ListDeploymentsInChunks(context.Background(), &types.ListOptions{Namespace: "default", Timeout: time.Second, ChunkSize: 2}, func(_ []appsv1.Deployment, restarted bool) error {
+		if restarted {
+			restarts++
+		}
+		return nil
+	})
+	if err != nil || restarts != 1 {
+		t.Errorf("expected one restarted list, got %d restarts (%v)", restarts, err)
+	}
+
+	client, _ = newPagingServer(t, 5, 4)
+	deployments, err := client.ListDeployments(context.Background(), &types.ListOptions{Namespace: "default", Timeout: time.Second, ChunkSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deployments.Items) != 5 || deployments.Items[0].Name != "web-0" || deployments.Items[4].Name != "web-4" {
+		t.Errorf("expected the 5 deployments of the restarted list once, got %d", len(deployments.Items))
+	}
+}
+
+func TestListInChunksGivesUpAfterRepeatedExpiry(t *testing.T) {
+	calls := 0
+	err := listInChunks(context.Background(), zerolog.Nop(), &types.ListOptions{Timeout: time.Second, ChunkSize: 1},
+		func(_ context.Context, listOptions metav1.ListOptions, _ bool) (string, error) {
+			calls++
+			if listOptions.Continue != "" {
+				return "", apierrors.NewResourceExpired("continue token too old")
+			}
+			return "next", nil
+		})
+	if err == nil {
+		t.Fatalf("expected an error after repeated expiry")
+	}
+	if want := 2 * (maxListRestarts + 1); calls != want {
+		t.Errorf("expected %d requests, got %d", want, calls)
+	}
+}
@@ -0,0 +1,50 @@
+package k8s
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
+)
+
+// GetDynamicClient returns a dynamic client for watching arbitrary resources
+func (c *Client) GetDynamicClient() (dynamic.Interface, error) {
+	dynamicClient, err := dynamic.NewForConfig(c.restConfig)
+	if err != nil {
+		c.logger.Error().Err(err).Msg("failed to create dynamic client")
+		return nil, errors.NewConnectionError("failed to create dynamic client", err)
+	}
+	return dynamicClient, nil
+}
+
+// ResolveResource maps a resource argument as accepted by kubectl, for example
+// "deployments", "sts", "configmaps" or "frontendpages.frontend.thegostev.com",
+// to the GroupVersionResource served by the cluster
+func (c *Client) ResolveResource(resource string) (schema.GroupVersionResource, error) {
+	logger := c.logger.With().Str("operation", "resolve-resource").Str("resource", resource).Logger()
+
+	discoveryClient := c.clientset.Discovery()
+	mapper := restmapper.NewShortcutExpander(
+		restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+		discoveryClient,
+	)
+
+	fullySpecified, groupResource := schema.ParseResourceArg(strings.ToLower(resource))
+	if fullySpecified != nil {
+		if gvr, err := mapper.ResourceFor(*fullySpecified); err == nil {
+			return gvr, nil
+		}
+	}
+	gvr, err := mapper.ResourceFor(groupResource.WithVersion(""))
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to resolve resource")
+		return schema.GroupVersionResource{}, errors.NewValidationError("resource", err.Error())
+	}
+
+	logger.Debug().Str("gvr", gvr.String()).Msg("resource resolved")
+	return gvr, nil
+}
@@ -0,0 +1,151 @@
+package k8s
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
+)
+
+// progressDeadlineExceeded is the reason of the Progressing condition of a
+// deployment whose rollout did not progress within progressDeadlineSeconds
+const progressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+// WaitForRollout blocks until the latest rollout of a deployment completed:
+// the deployment controller observed the latest generation and all replicas
+// are updated and available, with no old replicas left. The deployment is
+// followed with a watch. It returns a RolloutError if the rollout exceeded its
+// progress deadline, the deployment was deleted, or the wait timed out.
+func (c *Client) WaitForRollout(ctx context.Context, namespace, name string, options *types.RolloutOptions) error {
+	logger := c.logger.With().Str("operation", "wait-for-rollout").Logger()
+
+	// Basic validation
+	if namespace == "" {
+		return errors.NewValidationError("namespace", "cannot be empty")
+	}
+	if name == "" {
+		return errors.NewValidationError("name", "cannot be empty")
+	}
+	if err := options.Validate(); err != nil {
+		logger.Error().Err(err).Msg("invalid rollout options")
+		return errors.NewValidationError("rollout options", err.Error())
+	}
+
+	logger.Debug().
+		Str("namespace", namespace).
+		Str("name", name).
+		Dur("timeout", options.Timeout).
+		Msg("waiting for rollout")
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	// Watch the deployment only
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	deployments := c.clientset.AppsV1().Deployments(namespace)
+	listWatch := &cache.ListWatch{
+		ListFunc: func(listOptions metav1.ListOptions) (runtime.Object, error) {
+			listOptions.FieldSelector = fieldSelector
+			return deployments.List(ctx, listOptions)
+		},
+		WatchFunc: func(listOptions metav1.ListOptions) (watch.Interface, error) {
+			listOptions.FieldSelector = fieldSelector
+			return deployments.Watch(ctx, listOptions)
+		},
+	}
+
+	// Fail right away if the deployment does not exist
+	exists := func(store cache.Store) (bool, error) {
+		_, found, err := store.Get(&metav1.ObjectMeta{Namespace: namespace, Name: name})
+		if err == nil && !found {
+			err = errors.NewRolloutError(fmt.Sprintf("deployment %s/%s not found", namespace, name), nil)
+		}
+		return err != nil, err
+	}
+
+	lastMessage := ""
+	_, err := watchtools.UntilWithSync(ctx, listWatch, &appsv1.Deployment{}, exists, func(event watch.Event) (bool, error) {
+		deployment, ok := event.Object.(*appsv1.Deployment)
+		if !ok || deployment.Name != name {
+			return false, nil
+		}
+		if event.Type == watch.Deleted {
+			return false, errors.NewRolloutError(fmt.Sprintf("deployment %s/%s was deleted", namespace, name), nil)
+		}
+
+		message, done, err := RolloutStatus(deployment)
+		if message != lastMessage {
+			lastMessage = message
+			logger.Debug().Str("namespace", namespace).Str("name", name).Msg(message)
+			if options.OnProgress != nil {
+				options.OnProgress(message)
+			}
+		}
+		return done, err
+	})
+	if err != nil {
+		var rolloutErr *errors.RolloutError
+		if !stderrors.As(err, &rolloutErr) {
+			reason := "stopped waiting"
+			if stderrors.Is(ctx.Err(), context.DeadlineExceeded) {
+				reason = "timed out waiting"
+			}
+			if lastMessage == "" {
+				lastMessage = "no status observed"
+			}
+			err = errors.NewRolloutError(fmt.Sprintf("%s for rollout of deployment %s/%s, last status: %s", reason, namespace, name, lastMessage), err)
+		}
+		logger.Error().Err(err).Str("namespace", namespace).Str("name", name).Msg("rollout did not complete")
+		return err
+	}
+
+	logger.Info().
+		Str("namespace", namespace).
+		Str("name", name).
+		Msg("rollout completed successfully")
+
+	return nil
+}
+
+// RolloutStatus describes the rollout of a deployment the way kubectl rollout
+// status does, reporting whether it completed. It returns a RolloutError if
+// the rollout exceeded its progress deadline.
+func RolloutStatus(deployment *appsv1.Deployment) (string, bool, error) {
+	status := deployment.Status
+	if deployment.Generation > status.ObservedGeneration {
+		return fmt.Sprintf("waiting for deployment %q spec update to be observed", deployment.Name), false, nil
+	}
+
+	for _, condition := range status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == progressDeadlineExceeded {
+			return "", false, errors.NewRolloutError(fmt.Sprintf("deployment %q exceeded its progress deadline: %s", deployment.Name, condition.Message), nil)
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	switch {
+	case status.UpdatedReplicas < replicas:
+		return fmt.Sprintf("waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated", deployment.Name, status.UpdatedReplicas, replicas), false, nil
+	case status.Replicas > status.UpdatedReplicas:
+		return fmt.Sprintf("waiting for deployment %q rollout to finish: %d old replicas are pending termination", deployment.Name, status.Replicas-status.UpdatedReplicas), false, nil
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return fmt.Sprintf("waiting for deployment %q rollout to finish: %d of %d updated replicas are available", deployment.Name, status.AvailableReplicas, status.UpdatedReplicas), false, nil
+	}
+	return fmt.Sprintf("deployment %q successfully rolled out", deployment.Name), true, nil
+}
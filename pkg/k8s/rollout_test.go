@@ -0,0 +1,129 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/thegostev/go-kubernetes-controllers/internal/types"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
+)
+
+// newRollingDeployment returns a deployment of 3 replicas whose latest
+// generation was not observed yet
+func newRollingDeployment() *appsv1.Deployment {
+	deployment := newTestDeployment("nginx:1.25")
+	replicas := int32(3)
+	deployment.Spec.Replicas = &replicas
+	deployment.Generation = 2
+	deployment.Status = appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}
+	return deployment
+}
+
+func TestRolloutStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  appsv1.DeploymentStatus
+		want    string
+		done    bool
+		wantErr bool
+	}{
+		{"generation not observed", appsv1.DeploymentStatus{ObservedGeneration: 1}, "spec update to be observed", false, false},
+		{"updating", appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 1}, "1 out of 3 new replicas have been updated", false, false},
+		{"terminating old replicas", appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 3}, "1 old replicas are pending termination", false, false},
+		{"becoming available", appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2}, "2 of 3 updated replicas are available", false, false},
+		{"complete", appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}, "successfully rolled out", true, false},
+		{"progress deadline exceeded", appsv1.DeploymentStatus{ObservedGeneration: 2, Conditions: []appsv1.DeploymentCondition{{
+			Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: progressDeadlineExceeded,
+		}}}, "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := newRollingDeployment()
+			deployment.Status = tt.status
+			message, done, err := RolloutStatus(deployment)
+			if !strings.Contains(message, tt.want) || done != tt.done || (err != nil) != tt.wantErr {
+				t.Errorf("expected %q, done %v, error %v; got %q, %v, %v", tt.want, tt.done, tt.wantErr, message, done, err)
+			}
+		})
+	}
+}
+
+func TestWaitForRolloutFollowsTheDeployment(t *testing.T) {
+	client, clientset := newFakeDeploymentClient(newRollingDeployment())
+	ctx := context.Background()
+
+	progress := make(chan string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- client.WaitForRollout(ctx, "default", "web", &types.RolloutOptions{
+			Timeout:    10 * time.Second,
+			OnProgress: func(message string) { progress <- message },
+		})
+	}()
+
+	// The controller observes the new generation and rolls it out
+	update := func(status appsv1.DeploymentStatus) {
+		t.Helper()
+		deployment, err := clientset.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get deployment: %v", err)
+		}
+		deployment.Status = status
+		if _, err := clientset.AppsV1().Deployments("default").UpdateStatus(ctx, deployment, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("failed to update deployment: %v", err)
+		}
+	}
+	<-progress
+	update(appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 1, AvailableReplicas: 3})
+	<-progress
+	update(appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3})
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected the rollout to complete, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for WaitForRollout to return")
+	}
+}
+
+func TestWaitForRolloutFailures(t *testing.T) {
+	exceeded := newRollingDeployment()
+	exceeded.Status.ObservedGeneration = 2
+	exceeded.Status.UpdatedReplicas = 1
+	exceeded.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: progressDeadlineExceeded,
+		Message: `ReplicaSet "web-2" has timed out progressing.`,
+	}}
+
+	tests := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		want       string
+	}{
+		{"progress deadline exceeded", exceeded, "exceeded its progress deadline"},
+		{"timeout", newRollingDeployment(), "timed out waiting for rollout of deployment default/web, last status: waiting for deployment \"web\" spec update"},
+		{"not found", nil, "deployment default/web not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newFakeDeploymentClient()
+			if tt.deployment != nil {
+				client, _ = newFakeDeploymentClient(tt.deployment)
+			}
+			err := client.WaitForRollout(context.Background(), "default", "web", &types.RolloutOptions{Timeout: 200 * time.Millisecond})
+			if _, ok := err.(*errors.RolloutError); !ok || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected a rollout error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
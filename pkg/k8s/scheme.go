@@ -2,14 +2,20 @@ package k8s
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/api/v1beta1"
 )
 
 func NewScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
 	_ = appsv1.AddToScheme(scheme)
-	_ = v1alpha1.AddToScheme(scheme) // Add FrontendPage types
+	_ = autoscalingv2.AddToScheme(scheme) // HPAs scaling Deployments checked by the policy
+	_ = corev1.AddToScheme(scheme)        // ConfigMaps and Services owned by FrontendPages
+	_ = v1alpha1.AddToScheme(scheme)      // Add FrontendPage types
+	_ = v1beta1.AddToScheme(scheme)       // FrontendPage hub version, converted to and from v1alpha1
 	return scheme
 }
@@ -0,0 +1,196 @@
+package pageserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/render"
+)
+
+// Server serves FrontendPages rendered to HTML at /pages/{namespace}/{name}.
+// Pages are read from a controller-runtime cache on every request, so changes
+// to a FrontendPage are visible as soon as the cache observes them.
+type Server struct {
+	addr     string
+	reader   client.Reader
+	renderer *render.Renderer
+	logger   logr.Logger
+
+	mu       sync.RWMutex
+	rendered map[types.NamespacedName]renderedPage
+}
+
+// renderedPage is a rendered document together with the page resourceVersion and
+// renderer version it was rendered from
+type renderedPage struct {
+	resourceVersion string
+	rendererVersion uint64
+	html            []byte
+}
+
+var _ manager.Runnable = &Server{}
+var _ manager.LeaderElectionRunnable = &Server{}
+
+// NewServer creates a page server listening on addr that reads pages from reader
+func NewServer(addr string, reader client.Reader, renderer *render.Renderer) *Server {
+	return &Server{
+		addr:     addr,
+		reader:   reader,
+		renderer: renderer,
+		logger:   ctrllog.Log.WithName("page-server"),
+		rendered: make(map[types.NamespacedName]renderedPage),
+	}
+}
+
+// Handler returns the HTTP handler serving pages
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /pages/{namespace}/{name}", s.servePage)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("GET /{$}", s.serveIndex)
+	return mux
+}
+
+// Start serves pages until the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		s.logger.Info("starting page server", "addr", s.addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.logger.Info("stopping page server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// NeedLeaderElection returns false so that every replica serves pages
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// servePage renders a single FrontendPage
+func (s *Server) servePage(w http.ResponseWriter, r *http.Request) {
+	key := types.NamespacedName{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+
+	page := &v1alpha1.FrontendPage{}
+	if err := s.reader.Get(r.Context(), key, page); err != nil {
+		if apierrors.IsNotFound(err) {
+			s.forget(key)
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.Error(err, "failed to get FrontendPage", "namespace", key.Namespace, "name", key.Name)
+		http.Error(w, "failed to get page", http.StatusInternalServerError)
+		return
+	}
+
+	html, err := s.render(key, page)
+	if err != nil {
+		s.logger.Error(err, "failed to render FrontendPage", "namespace", key.Namespace, "name", key.Name)
+		http.Error(w, "failed to render page", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(html)
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Frontend Pages</title></head>
+<body>
+<h1>Frontend Pages</h1>
+<ul>
+{{- range .Items }}
+<li><a href="/pages/{{ .Namespace }}/{{ .Name }}">{{ .Namespace }}/{{ .Name }}</a> - {{ .Spec.Title }}</li>
+{{- end }}
+</ul>
+</body>
+</html>
+`))
+
+// serveIndex lists all FrontendPages known to the cache
+func (s *Server) serveIndex(w http.ResponseWriter, r *http.Request) {
+	pages := &v1alpha1.FrontendPageList{}
+	if err := s.reader.List(r.Context(), pages); err != nil {
+		s.logger.Error(err, "failed to list FrontendPages")
+		http.Error(w, "failed to list pages", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := indexTemplate.Execute(w, pages); err != nil {
+		s.logger.Error(err, "failed to render page index")
+	}
+}
+
+// render returns the rendered document for the page, re-rendering only when
+// the page has changed since it was last served
+func (s *Server) render(key types.NamespacedName, page *v1alpha1.FrontendPage) ([]byte, error) {
+	rendererVersion := s.renderer.Version()
+	s.mu.RLock()
+	cached, ok := s.rendered[key]
+	s.mu.RUnlock()
+	if ok && cached.resourceVersion == page.ResourceVersion && cached.rendererVersion == rendererVersion {
+		return cached.html, nil
+	}
+
+	html, err := s.renderer.RenderString(page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render page: %w", err)
+	}
+
+	s.mu.Lock()
+	s.rendered[key] = renderedPage{
+		resourceVersion: page.ResourceVersion,
+		rendererVersion: rendererVersion,
+		html:            []byte(html),
+	}
+	s.mu.Unlock()
+	return []byte(html), nil
+}
+
+// Cleanup drops the rendered document of a FrontendPage that is being deleted.
+// It satisfies the FrontendPage controller's CleanupHook interface.
+func (s *Server) Cleanup(ctx context.Context, page *v1alpha1.FrontendPage) error {
+	s.forget(types.NamespacedName{Namespace: page.Namespace, Name: page.Name})
+	return nil
+}
+
+// forget drops the rendered document for a page that no longer exists
+func (s *Server) forget(key types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.rendered, key)
+}
@@ -0,0 +1,69 @@
+package pageserver
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/render"
+)
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestServerServesLivePages(t *testing.T) {
+	page := &v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: "default"},
+		Spec: v1alpha1.FrontendPageSpec{
+			Title:      "Home",
+			Template:   "landing",
+			Components: []v1alpha1.Component{{Name: "cta", Type: "button"}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(k8s.NewScheme()).WithObjects(page).Build()
+	srv := httptest.NewServer(NewServer(":0", c, render.NewRenderer()).Handler())
+	defer srv.Close()
+
+	status, body := get(t, srv.URL+"/pages/default/home")
+	if status != http.StatusOK || !strings.Contains(body, "<h1>Home</h1>") {
+		t.Fatalf("unexpected response %d:\n%s", status, body)
+	}
+
+	// Changes to the FrontendPage are served without a restart
+	page.Spec.Title = "Welcome"
+	if err := c.Update(context.Background(), page); err != nil {
+		t.Fatalf("failed to update page: %v", err)
+	}
+	_, body = get(t, srv.URL+"/pages/default/home")
+	if !strings.Contains(body, "<h1>Welcome</h1>") {
+		t.Errorf("expected updated title, got\n%s", body)
+	}
+
+	_, body = get(t, srv.URL+"/")
+	if !strings.Contains(body, `href="/pages/default/home"`) {
+		t.Errorf("expected index to link the page, got\n%s", body)
+	}
+
+	if status, _ := get(t, srv.URL+"/pages/default/missing"); status != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing page, got %d", status)
+	}
+}
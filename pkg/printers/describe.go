@@ -0,0 +1,151 @@
+package printers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+)
+
+// DescribeFrontendPage prints a frontend page the way kubectl describe does:
+// metadata, spec, a table of components, status, children and Events. Ages
+// are relative to now.
+func DescribeFrontendPage(w io.Writer, description *k8s.FrontendPageDescription, now time.Time) error {
+	page := description.Page
+	age := func(t metav1.Time) string {
+		if t.IsZero() {
+			return "<unknown>"
+		}
+		return duration.HumanDuration(now.Sub(t.Time))
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "Name:\t%s\n", page.Name)
+	fmt.Fprintf(tw, "Namespace:\t%s\n", page.Namespace)
+	fmt.Fprintf(tw, "Labels:\t%s\n", joinMap(page.Labels))
+	fmt.Fprintf(tw, "Annotations:\t%s\n", joinMap(page.Annotations))
+	if gvk, err := kindOf(page); err == nil {
+		fmt.Fprintf(tw, "API Version:\t%s\n", gvk.GroupVersion())
+	}
+	fmt.Fprintf(tw, "Created:\t%s (%s ago)\n", page.CreationTimestamp.UTC().Format(time.RFC3339), age(page.CreationTimestamp))
+	if page.DeletionTimestamp != nil {
+		fmt.Fprintf(tw, "Deleting:\tsince %s, finalizers %s\n", page.DeletionTimestamp.UTC().Format(time.RFC3339), strings.Join(page.Finalizers, ","))
+	}
+
+	fmt.Fprintln(tw, "Spec:")
+	fmt.Fprintf(tw, "  Title:\t%s\n", orNone(page.Spec.Title))
+	fmt.Fprintf(tw, "  Template:\t%s\n", orNone(page.Spec.Template))
+	fmt.Fprintf(tw, "  Theme:\t%s\n", orNone(page.Spec.Theme))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	// Each table is flushed on its own, so columns align within the section
+	tw = tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Components:")
+	if len(page.Spec.Components) == 0 {
+		fmt.Fprintln(tw, "  <none>")
+	} else {
+		fmt.Fprintln(tw, "  NAME\tTYPE\tCONFIG")
+		for _, component := range page.Spec.Components {
+			config := none
+			if len(component.Config) > 0 {
+				data, err := json.Marshal(component.Config)
+				if err != nil {
+					return fmt.Errorf("failed to print config of component %s: %w", component.Name, err)
+				}
+				config = string(data)
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", component.Name, component.Type, config)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	status := page.Status
+	tw = tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Status:")
+	fmt.Fprintf(tw, "  Phase:\t%s\n", orNone(status.Phase))
+	fmt.Fprintf(tw, "  Message:\t%s\n", orNone(status.Message))
+	fmt.Fprintf(tw, "  URL:\t%s\n", orNone(status.URL))
+	fmt.Fprintf(tw, "  Observed Generation:\t%d (generation %d)\n", status.ObservedGeneration, page.Generation)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	tw = tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "  Conditions:")
+	if len(status.Conditions) == 0 {
+		fmt.Fprintln(tw, "    <none>")
+	} else {
+		fmt.Fprintln(tw, "    TYPE\tSTATUS\tREASON\tAGE\tMESSAGE")
+		for _, condition := range status.Conditions {
+			fmt.Fprintf(tw, "    %s\t%s\t%s\t%s\t%s\n", condition.Type, condition.Status, orNone(condition.Reason),
+				age(condition.LastTransitionTime), orNone(condition.Message))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	tw = tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Children:")
+	if len(description.Children) == 0 {
+		fmt.Fprintln(tw, "  <none>")
+	} else {
+		fmt.Fprintln(tw, "  KIND\tNAME\tAGE")
+		for _, child := range description.Children {
+			kind := fmt.Sprintf("%T", child)
+			if gvk, err := kindOf(child); err == nil {
+				kind = gvk.Kind
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", kind, child.GetName(), age(child.GetCreationTimestamp()))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	tw = tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Events:")
+	if len(description.Events) == 0 {
+		fmt.Fprintln(tw, "  <none>")
+	} else {
+		fmt.Fprintln(tw, "  TYPE\tREASON\tAGE\tFROM\tMESSAGE")
+		for i := range description.Events {
+			event := &description.Events[i]
+			eventAge := age(k8s.EventTime(event))
+			if event.Count > 1 && !event.FirstTimestamp.IsZero() {
+				eventAge = fmt.Sprintf("%s (x%d over %s)", eventAge, event.Count, age(event.FirstTimestamp))
+			}
+			from := event.Source.Component
+			if from == "" {
+				from = event.ReportingController
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", event.Type, event.Reason, eventAge, orNone(from), strings.TrimSpace(event.Message))
+		}
+	}
+	return tw.Flush()
+}
+
+// joinMap prints labels or annotations as sorted key=value pairs, one per line
+func joinMap(m map[string]string) string {
+	if len(m) == 0 {
+		return none
+	}
+	pairs := make([]string, 0, len(m))
+	for key, value := range m {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\n\t")
+}
@@ -0,0 +1,232 @@
+package printers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+
+	"github.com/thegostev/go-kubernetes-controllers/pkg/errors"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+)
+
+// Output formats accepted by NewPrinter; jsonpath and go-template take their
+// template after "=", e.g. jsonpath={.items[*].metadata.name}
+const (
+	TableFormat      = "table"
+	WideFormat       = "wide"
+	JSONFormat       = "json"
+	YAMLFormat       = "yaml"
+	NameFormat       = "name"
+	JSONPathFormat   = "jsonpath"
+	GoTemplateFormat = "go-template"
+)
+
+// Formats lists the output formats for help texts
+var Formats = []string{TableFormat, WideFormat, JSONFormat, YAMLFormat, NameFormat, JSONPathFormat + "=...", GoTemplateFormat + "=..."}
+
+// Printer prints deployments and frontend pages
+type Printer interface {
+	// Print prints a *appsv1.DeploymentList, *v1alpha1.FrontendPageList or a
+	// single item of one of them
+	Print(w io.Writer, object runtime.Object) error
+}
+
+// NewPrinter creates the printer of an output format; "" prints a table
+func NewPrinter(output string) (Printer, error) {
+	format, arg, _ := strings.Cut(output, "=")
+	switch format {
+	case "", TableFormat:
+		return &tablePrinter{now: time.Now}, nil
+	case WideFormat:
+		return &tablePrinter{wide: true, now: time.Now}, nil
+	case JSONFormat:
+		return &jsonPrinter{}, nil
+	case YAMLFormat:
+		return &yamlPrinter{}, nil
+	case NameFormat:
+		return &namePrinter{}, nil
+	case JSONPathFormat:
+		if arg == "" {
+			return nil, errors.NewValidationError("output", "jsonpath template cannot be empty")
+		}
+		parser := jsonpath.New("output").AllowMissingKeys(true)
+		if err := parser.Parse(arg); err != nil {
+			return nil, errors.NewValidationError("output", fmt.Sprintf("invalid jsonpath template: %v", err))
+		}
+		return &jsonPathPrinter{parser: parser}, nil
+	case GoTemplateFormat:
+		if arg == "" {
+			return nil, errors.NewValidationError("output", "go-template cannot be empty")
+		}
+		tmpl, err := template.New("output").Parse(arg)
+		if err != nil {
+			return nil, errors.NewValidationError("output", fmt.Sprintf("invalid go-template: %v", err))
+		}
+		return &templatePrinter{template: tmpl}, nil
+	}
+	return nil, errors.NewValidationError("output", fmt.Sprintf("unsupported format %q, expected one of %s", output, strings.Join(Formats, ", ")))
+}
+
+// jsonPrinter prints JSON, the way kubectl get -o json does
+type jsonPrinter struct{}
+
+func (p *jsonPrinter) Print(w io.Writer, object runtime.Object) error {
+	data, err := toUnstructured(object)
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(data, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", out)
+	return err
+}
+
+// yamlPrinter prints YAML, the way kubectl get -o yaml does
+type yamlPrinter struct{}
+
+func (p *yamlPrinter) Print(w io.Writer, object runtime.Object) error {
+	data, err := toUnstructured(object)
+	if err != nil {
+		return err
+	}
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// namePrinter prints resource/name per item, e.g. deployment.apps/web
+type namePrinter struct{}
+
+func (p *namePrinter) Print(w io.Writer, object runtime.Object) error {
+	data, err := toUnstructured(object)
+	if err != nil {
+		return err
+	}
+	items := []interface{}{data}
+	if meta.IsListType(object) {
+		items = data["items"].([]interface{})
+	}
+	for _, item := range items {
+		item := item.(map[string]interface{})
+		metadata, _ := item["metadata"].(map[string]interface{})
+		if _, err := fmt.Fprintf(w, "%s/%s\n", resourceName(item), metadata["name"]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonPathPrinter prints through a jsonpath template
+type jsonPathPrinter struct {
+	parser *jsonpath.JSONPath
+}
+
+func (p *jsonPathPrinter) Print(w io.Writer, object runtime.Object) error {
+	data, err := toUnstructured(object)
+	if err != nil {
+		return err
+	}
+	if err := p.parser.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to execute jsonpath template: %w", err)
+	}
+	return nil
+}
+
+// templatePrinter prints through a Go template
+type templatePrinter struct {
+	template *template.Template
+}
+
+func (p *templatePrinter) Print(w io.Writer, object runtime.Object) error {
+	data, err := toUnstructured(object)
+	if err != nil {
+		return err
+	}
+	// Buffer the output so a failing template prints nothing
+	var buf bytes.Buffer
+	if err := p.template.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute go-template: %w", err)
+	}
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+// scheme resolves the apiVersion and kind of printed objects
+var scheme = k8s.NewScheme()
+
+// toUnstructured converts a typed object to an unstructured one that carries
+// its apiVersion and kind, which typed clients strip when decoding. Lists
+// become a "List" of such items.
+func toUnstructured(object runtime.Object) (map[string]interface{}, error) {
+	if !meta.IsListType(object) {
+		return toUnstructuredItem(object)
+	}
+
+	objects, err := meta.ExtractList(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract list items: %w", err)
+	}
+	items := make([]interface{}, 0, len(objects))
+	for _, object := range objects {
+		item, err := toUnstructuredItem(object)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"metadata":   map[string]interface{}{"resourceVersion": ""},
+		"items":      items,
+	}, nil
+}
+
+// toUnstructuredItem converts a typed object and sets its apiVersion and kind
+func toUnstructuredItem(object runtime.Object) (map[string]interface{}, error) {
+	gvk, err := kindOf(object)
+	if err != nil {
+		return nil, err
+	}
+	item, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %T: %w", object, err)
+	}
+	item["apiVersion"], item["kind"] = gvk.GroupVersion().String(), gvk.Kind
+	return item, nil
+}
+
+// kindOf returns the group, version and kind of a typed object
+func kindOf(object runtime.Object) (schema.GroupVersionKind, error) {
+	gvks, _, err := scheme.ObjectKinds(object)
+	if err != nil {
+		return schema.GroupVersionKind{}, fmt.Errorf("failed to get kind of %T: %w", object, err)
+	}
+	return gvks[0], nil
+}
+
+// resourceName returns the lowercase kind.group of an unstructured item
+func resourceName(item map[string]interface{}) string {
+	kind := strings.ToLower(item["kind"].(string))
+	group, _, found := strings.Cut(item["apiVersion"].(string), "/")
+	if !found {
+		return kind
+	}
+	return kind + "." + group
+}
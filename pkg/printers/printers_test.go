@@ -0,0 +1,187 @@
+package printers
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+var testNow = time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+func newTestDeploymentList() *appsv1.DeploymentList {
+	replicas := int32(3)
+	return &appsv1.DeploymentList{Items: []appsv1.Deployment{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", CreationTimestamp: metav1.NewTime(testNow.Add(-50 * time.Hour))},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "app", Image: "nginx:1.25"},
+					{Name: "proxy", Image: "envoyproxy/envoy:v1.30"},
+				}}},
+			},
+			Status: appsv1.DeploymentStatus{Replicas: 3, ReadyReplicas: 2, UpdatedReplicas: 3, AvailableReplicas: 2},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "shop", CreationTimestamp: metav1.NewTime(testNow.Add(-90 * time.Second))},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "worker"}},
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "worker", Image: "busybox"},
+				}}},
+			},
+		},
+	}}
+}
+
+func newTestFrontendPageList() *v1alpha1.FrontendPageList {
+	return &v1alpha1.FrontendPageList{Items: []v1alpha1.FrontendPage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: "shop", CreationTimestamp: metav1.NewTime(testNow.Add(-36 * time.Hour))},
+			Spec:       v1alpha1.FrontendPageSpec{Title: "Home", Template: "landing", Theme: "dark"},
+			Status: v1alpha1.FrontendPageStatus{
+				Phase: v1alpha1.PhaseReady,
+				URL:   "http://home.shop.svc",
+				Conditions: []metav1.Condition{
+					{Type: v1alpha1.ConditionReady, Status: metav1.ConditionTrue, Reason: "Available", LastTransitionTime: metav1.NewTime(testNow)},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "draft", Namespace: "shop", CreationTimestamp: metav1.NewTime(testNow.Add(-5 * time.Minute))},
+			Spec:       v1alpha1.FrontendPageSpec{Title: "Draft", Template: "default"},
+		},
+	}}
+}
+
+func TestPrintersGolden(t *testing.T) {
+	lists := map[string]runtime.Object{
+		"deployments":   newTestDeploymentList(),
+		"frontendpages": newTestFrontendPageList(),
+	}
+	outputs := map[string]string{
+		"table":       "",
+		"wide":        "wide",
+		"json":        "json",
+		"yaml":        "yaml",
+		"name":        "name",
+		"jsonpath":    `jsonpath={range .items[*]}{.metadata.name}{"\t"}{.kind}{"\n"}{end}`,
+		"go-template": `go-template={{range .items}}{{.metadata.name}} {{.metadata.namespace}}{{"\n"}}{{end}}`,
+	}
+
+	for listName, list := range lists {
+		for outputName, output := range outputs {
+			name := listName + "-" + outputName
+			t.Run(name, func(t *testing.T) {
+				printer, err := NewPrinter(output)
+				if err != nil {
+					t.Fatalf("failed to create printer: %v", err)
+				}
+				if table, ok := printer.(*tablePrinter); ok {
+					table.now = func() time.Time { return testNow }
+				}
+
+				var buf bytes.Buffer
+				if err := printer.Print(&buf, list); err != nil {
+					t.Fatalf("failed to print: %v", err)
+				}
+
+				assertGolden(t, name, buf.Bytes())
+			})
+		}
+	}
+}
+
+// assertGolden compares output with testdata/<name>.golden, rewriting the
+// file first when the tests run with -update
+func assertGolden(t *testing.T, name string, output []byte) {
+	t.Helper()
+	golden := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(golden, output, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if string(output) != string(want) {
+		t.Errorf("output differs from %s, rerun with -update if intended\ngot:\n%s\nwant:\n%s", golden, output, want)
+	}
+}
+
+func TestPrintSingleObjects(t *testing.T) {
+	page := &newTestFrontendPageList().Items[0]
+	for _, output := range []string{"name", "jsonpath={.kind}/{.metadata.name}"} {
+		printer, err := NewPrinter(output)
+		if err != nil {
+			t.Fatalf("failed to create printer: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := printer.Print(&buf, page); err != nil {
+			t.Fatalf("failed to print: %v", err)
+		}
+		assertGolden(t, "frontendpage-"+strings.SplitN(output, "=", 2)[0], buf.Bytes())
+	}
+}
+
+func TestDescribeFrontendPage(t *testing.T) {
+	page := newTestFrontendPageList().Items[0]
+	page.Labels = map[string]string{"team": "web", "app": "shop"}
+	page.Generation = 2
+	page.Status.ObservedGeneration = 2
+	page.Spec.Components = []v1alpha1.Component{
+		{Name: "hero", Type: "text", Config: map[string]interface{}{"text": "Welcome"}},
+		{Name: "cart", Type: "button"},
+	}
+	description := &k8s.FrontendPageDescription{
+		Page: &page,
+		Children: []ctrlclient.Object{
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "frontendpage-home", CreationTimestamp: metav1.NewTime(testNow.Add(-time.Hour))}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "frontendpage-home", CreationTimestamp: metav1.NewTime(testNow.Add(-time.Hour))}},
+		},
+		Events: []corev1.Event{{
+			Type: corev1.EventTypeWarning, Reason: "RenderFailed", Message: "unknown template",
+			Count: 3, FirstTimestamp: metav1.NewTime(testNow.Add(-10 * time.Minute)), LastTimestamp: metav1.NewTime(testNow.Add(-2 * time.Minute)),
+			Source: corev1.EventSource{Component: "frontendpage-controller"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := DescribeFrontendPage(&buf, description, testNow); err != nil {
+		t.Fatalf("failed to describe: %v", err)
+	}
+	assertGolden(t, "frontendpage-describe", buf.Bytes())
+}
+
+func TestNewPrinterRejectsInvalidFormats(t *testing.T) {
+	for _, output := range []string{"xml", "jsonpath=", "jsonpath={.items[", "go-template={{.items"} {
+		if _, err := NewPrinter(output); err == nil {
+			t.Errorf("expected %q to be rejected", output)
+		}
+	}
+}
+
+func TestTablePrinterRejectsUnknownLists(t *testing.T) {
+	printer, _ := NewPrinter("table")
+	if err := printer.Print(&bytes.Buffer{}, &corev1.PodList{}); err == nil {
+		t.Errorf("expected a pod list to be rejected")
+	}
+}
@@ -0,0 +1,142 @@
+package printers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+)
+
+// none is printed for empty table cells
+const none = "<none>"
+
+// tablePrinter prints a list as aligned columns; wide adds the columns
+// kubectl get -o wide shows
+type tablePrinter struct {
+	wide bool
+	now  func() time.Time
+}
+
+func (p *tablePrinter) Print(w io.Writer, object runtime.Object) error {
+	// Single objects print as a list of one
+	switch object := object.(type) {
+	case *appsv1.Deployment:
+		return p.Print(w, &appsv1.DeploymentList{Items: []appsv1.Deployment{*object}})
+	case *v1alpha1.FrontendPage:
+		return p.Print(w, &v1alpha1.FrontendPageList{Items: []v1alpha1.FrontendPage{*object}})
+	}
+
+	var headers, wideHeaders []string
+	var rows, wideRows [][]string
+	switch list := object.(type) {
+	case *appsv1.DeploymentList:
+		headers = []string{"NAME", "READY", "UP-TO-DATE", "AVAILABLE", "AGE", "IMAGES"}
+		wideHeaders = []string{"CONTAINERS", "SELECTOR"}
+		for i := range list.Items {
+			row, wideRow := p.deploymentRow(&list.Items[i])
+			rows, wideRows = append(rows, row), append(wideRows, wideRow)
+		}
+	case *v1alpha1.FrontendPageList:
+		headers = []string{"NAME", "TITLE", "TEMPLATE", "PHASE", "READY", "AGE"}
+		wideHeaders = []string{"THEME", "URL"}
+		for i := range list.Items {
+			row, wideRow := p.frontendPageRow(&list.Items[i])
+			rows, wideRows = append(rows, row), append(wideRows, wideRow)
+		}
+	default:
+		return fmt.Errorf("cannot print %T as a table", list)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+	if p.wide {
+		headers = append(headers, wideHeaders...)
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for i, row := range rows {
+		if p.wide {
+			row = append(row, wideRows[i]...)
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// deploymentRow returns the columns of a deployment, matching kubectl
+func (p *tablePrinter) deploymentRow(deployment *appsv1.Deployment) ([]string, []string) {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+
+	var names, images []string
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		names = append(names, container.Name)
+		images = append(images, container.Image)
+	}
+
+	selector := none
+	if deployment.Spec.Selector != nil {
+		if s, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector); err == nil && !s.Empty() {
+			selector = s.String()
+		}
+	}
+
+	return []string{
+		deployment.Name,
+		fmt.Sprintf("%d/%d", status.ReadyReplicas, replicas),
+		fmt.Sprint(status.UpdatedReplicas),
+		fmt.Sprint(status.AvailableReplicas),
+		p.age(deployment.CreationTimestamp),
+		orNone(strings.Join(images, ",")),
+	}, []string{
+		orNone(strings.Join(names, ",")),
+		selector,
+	}
+}
+
+// frontendPageRow returns the columns of a frontend page, matching the
+// printer columns of the CRD
+func (p *tablePrinter) frontendPageRow(page *v1alpha1.FrontendPage) ([]string, []string) {
+	ready := ""
+	for _, condition := range page.Status.Conditions {
+		if condition.Type == v1alpha1.ConditionReady {
+			ready = string(condition.Status)
+		}
+	}
+
+	return []string{
+		page.Name,
+		orNone(page.Spec.Title),
+		orNone(page.Spec.Template),
+		orNone(page.Status.Phase),
+		orNone(ready),
+		p.age(page.CreationTimestamp),
+	}, []string{
+		orNone(page.Spec.Theme),
+		orNone(page.Status.URL),
+	}
+}
+
+// age formats the time since created the way kubectl does, e.g. 3d4h
+func (p *tablePrinter) age(created metav1.Time) string {
+	if created.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(p.now().Sub(created.Time))
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return none
+	}
+	return value
+}
@@ -0,0 +1,74 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/api/v1beta1"
+)
+
+var (
+	// colorPattern accepts hex colors, color names and rgb()/hsl() functions
+	colorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|[a-zA-Z]+|(rgb|rgba|hsl|hsla)\([0-9., %]+\))$`)
+	// fontFamilyPattern accepts comma separated, optionally quoted font names
+	fontFamilyPattern = regexp.MustCompile(`^[a-zA-Z0-9 ,'"-]+$`)
+)
+
+// v1beta1Spec returns the v1beta1 form of the page spec, which carries the
+// layout, component order and theme overrides kept in the page's annotations
+func v1beta1Spec(page *v1alpha1.FrontendPage) (v1beta1.FrontendPageSpec, error) {
+	hub := &v1beta1.FrontendPage{}
+	if err := page.DeepCopy().ConvertTo(hub); err != nil {
+		return v1beta1.FrontendPageSpec{}, fmt.Errorf("failed to read layout of page: %w", err)
+	}
+	return hub.Spec, nil
+}
+
+// componentOrder returns the indexes of the components sorted by ascending
+// order hint; components with equal order keep their list order
+func componentOrder(components []v1beta1.Component) []int {
+	order := make([]int, len(components))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return components[order[i]].Order < components[order[j]].Order
+	})
+	return order
+}
+
+// overrideCSS returns the stylesheet rules applying the layout and the theme
+// overrides of spec after the theme stylesheet. Values that are not plain
+// colors or font names are ignored, so they cannot escape the stylesheet.
+func overrideCSS(spec v1beta1.FrontendPageSpec) string {
+	var rules []string
+
+	switch spec.Layout.Type {
+	case v1beta1.LayoutStack:
+		rules = append(rules, "main, main.grid { display: block; }")
+	case v1beta1.LayoutGrid:
+		columns := "repeat(auto-fill, minmax(20rem, 1fr))"
+		if spec.Layout.Columns > 0 {
+			columns = fmt.Sprintf("repeat(%d, minmax(0, 1fr))", spec.Layout.Columns)
+		}
+		rules = append(rules, fmt.Sprintf("main, main.grid { display: grid; grid-template-columns: %s; gap: 1rem; }", columns))
+	}
+
+	var body []string
+	if theme := spec.Theme; colorPattern.MatchString(theme.BackgroundColor) {
+		body = append(body, "background: "+theme.BackgroundColor+";")
+	}
+	if theme := spec.Theme; fontFamilyPattern.MatchString(theme.FontFamily) {
+		body = append(body, "font-family: "+theme.FontFamily+";")
+	}
+	if len(body) > 0 {
+		rules = append(rules, "body { "+strings.Join(body, " ")+" }")
+	}
+	if color := spec.Theme.PrimaryColor; colorPattern.MatchString(color) {
+		rules = append(rules, fmt.Sprintf("h1 { color: %s; }\n.component { border-color: %s; }", color, color))
+	}
+	return strings.Join(rules, "\n")
+}
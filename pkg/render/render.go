@@ -0,0 +1,225 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/components"
+)
+
+const (
+	// DefaultTemplate is the page template used when Spec.Template is not registered
+	DefaultTemplate = "default"
+
+	// DefaultTheme is the theme used when Spec.Theme is empty or not registered
+	DefaultTheme = "light"
+)
+
+// PageData is the data passed to page templates
+type PageData struct {
+	Title      string
+	Template   string
+	Theme      string
+	ThemeCSS   template.CSS
+	Components []template.HTML
+	Page       *v1alpha1.FrontendPage
+}
+
+// Renderer renders FrontendPage specs to HTML using pluggable Go templates.
+// Page templates are selected by Spec.Template and theme stylesheets by Spec.Theme;
+// components are rendered by the type registered for Component.Type, in the
+// order of their v1beta1 order hints. The v1beta1 layout and theme overrides are
+// appended to the theme stylesheet and apply to the <main> element of templates.
+// It is safe for concurrent use.
+type Renderer struct {
+	mu        sync.RWMutex
+	templates map[string]*template.Template
+	themes    map[string]string
+	registry  *components.Registry
+	version   uint64
+}
+
+// NewRenderer creates a renderer with the built-in templates, themes and component types registered
+func NewRenderer() *Renderer {
+	return NewRendererForRegistry(components.NewDefaultRegistry())
+}
+
+// NewRendererForRegistry creates a renderer with the built-in templates and themes
+// that renders components using the given registry
+func NewRendererForRegistry(registry *components.Registry) *Renderer {
+	r := &Renderer{
+		templates: make(map[string]*template.Template),
+		themes:    make(map[string]string),
+		registry:  registry,
+	}
+	for name, text := range builtinTemplates {
+		template.Must(r.templateFor(name, text))
+	}
+	for name, css := range builtinThemes {
+		r.RegisterTheme(name, css)
+	}
+	return r
+}
+
+// Registry returns the component registry used to render and validate components
+func (r *Renderer) Registry() *components.Registry {
+	return r.registry
+}
+
+// RegisterTemplate registers (or replaces) the page template with the given name
+func (r *Renderer) RegisterTemplate(name, text string) error {
+	_, err := r.templateFor(name, text)
+	return err
+}
+
+// RegisterComponent registers (or replaces) the template for the given component
+// type in the registry. A type that is already registered keeps its config
+// schema; a new type accepts any config object. Use Registry().Register to set a
+// schema or a custom render function.
+func (r *Renderer) RegisterComponent(componentType, text string) error {
+	render, err := components.TemplateRender(componentType, text)
+	if err != nil {
+		return err
+	}
+	schemaJSON := `{"type": "object"}`
+	if t, ok := r.registry.Lookup(componentType); ok {
+		schema, err := json.Marshal(t.Schema)
+		if err != nil {
+			return fmt.Errorf("failed to encode schema of component type %q: %w", componentType, err)
+		}
+		schemaJSON = string(schema)
+	}
+	return r.registry.Register(componentType, schemaJSON, render)
+}
+
+// RegisterTheme registers (or replaces) the stylesheet for the given theme
+func (r *Renderer) RegisterTheme(name, css string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.themes[name] = css
+	r.version++
+}
+
+// Version changes every time a template, theme or component type is registered,
+// allowing callers that cache rendered output to detect stale entries
+func (r *Renderer) Version() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.version + r.registry.Version()
+}
+
+// HasTemplate reports whether a page template with the given name is registered
+func (r *Renderer) HasTemplate(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.templates[name]
+	return ok
+}
+
+// Templates returns the sorted names of all registered page templates
+func (r *Renderer) Templates() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.templates))
+	for name := range r.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render writes the HTML document for the given FrontendPage to w
+func (r *Renderer) Render(w io.Writer, page *v1alpha1.FrontendPage) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	data := PageData{
+		Title:    page.Spec.Title,
+		Template: page.Spec.Template,
+		Theme:    page.Spec.Theme,
+		Page:     page,
+	}
+	if data.Theme == "" {
+		data.Theme = DefaultTheme
+	}
+	css, ok := r.themes[data.Theme]
+	if !ok {
+		css = r.themes[DefaultTheme]
+	}
+
+	// Layout, component order and theme overrides are v1beta1 fields
+	spec, err := v1beta1Spec(page)
+	if err != nil {
+		return err
+	}
+	if overrides := overrideCSS(spec); overrides != "" {
+		css += "\n" + overrides
+	}
+	data.ThemeCSS = template.CSS(css)
+
+	for _, i := range componentOrder(spec.Components) {
+		html, err := r.renderComponent(page.Spec.Components[i])
+		if err != nil {
+			return err
+		}
+		data.Components = append(data.Components, html)
+	}
+
+	tmpl, ok := r.templates[page.Spec.Template]
+	if !ok {
+		tmpl = r.templates[DefaultTemplate]
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to execute template %q: %w", tmpl.Name(), err)
+	}
+	return nil
+}
+
+// RenderString renders the given FrontendPage and returns the HTML document
+func (r *Renderer) RenderString(page *v1alpha1.FrontendPage) (string, error) {
+	var buf bytes.Buffer
+	if err := r.Render(&buf, page); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// unknownComponent is rendered in place of components without a registered type
+var unknownComponent = template.Must(template.New("unknown").Parse(
+	`<section class="component component-{{ .Type }}" id="{{ .Name }}"></section>`))
+
+// renderComponent renders a single component with its registered type
+func (r *Renderer) renderComponent(component v1alpha1.Component) (template.HTML, error) {
+	data := components.Data{Name: component.Name, Type: component.Type, Config: component.Config}
+	t, ok := r.registry.Lookup(component.Type)
+	if !ok {
+		var buf bytes.Buffer
+		if err := unknownComponent.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return template.HTML(buf.String()), nil
+	}
+	html, err := t.Render(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to render component %q: %w", component.Name, err)
+	}
+	return html, nil
+}
+
+func (r *Renderer) templateFor(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %q: %w", name, err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates[name] = tmpl
+	r.version++
+	return tmpl, nil
+}
@@ -0,0 +1,180 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/api/v1beta1"
+)
+
+func newTestPage() *v1alpha1.FrontendPage {
+	return &v1alpha1.FrontendPage{
+		Spec: v1alpha1.FrontendPageSpec{
+			Title:    "Example <Dashboard>",
+			Template: "dashboard",
+			Theme:    "dark",
+			Components: []v1alpha1.Component{
+				{Name: "metrics", Type: "table", Config: map[string]interface{}{"columns": []interface{}{"Name", "Value"}}},
+				{Name: "actions", Type: "button", Config: map[string]interface{}{"actions": []interface{}{"refresh"}}},
+				{Name: "custom", Type: "unknown"},
+			},
+		},
+	}
+}
+
+func TestRendererRendersSpec(t *testing.T) {
+	html, err := NewRenderer().RenderString(newTestPage())
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"<title>Example &lt;Dashboard&gt;</title>",
+		`class="template-dashboard theme-dark"`,
+		"#0d1117",
+		"<th>Name</th><th>Value</th>",
+		`data-action="refresh"`,
+		`class="component component-unknown" id="custom"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected rendered page to contain %q\n%s", want, html)
+		}
+	}
+}
+
+func TestRendererPluggableTemplates(t *testing.T) {
+	r := NewRenderer()
+	version := r.Version()
+
+	if err := r.RegisterTemplate("minimal", `<h1>{{ .Title }}</h1>{{ range .Components }}{{ . }}{{ end }}`); err != nil {
+		t.Fatalf("failed to register template: %v", err)
+	}
+	if err := r.RegisterComponent("table", `<table id="{{ .Name }}"></table>`); err != nil {
+		t.Fatalf("failed to register component: %v", err)
+	}
+	if r.Version() == version {
+		t.Errorf("expected renderer version to change after registration")
+	}
+	if !r.HasTemplate("minimal") {
+		t.Errorf("expected minimal template to be registered")
+	}
+
+	page := newTestPage()
+	page.Spec.Template = "minimal"
+	page.Spec.Components = page.Spec.Components[:1]
+	html, err := r.RenderString(page)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if html != `<h1>Example &lt;Dashboard&gt;</h1><table id="metrics"></table>` {
+		t.Errorf("unexpected output: %s", html)
+	}
+
+	if err := r.RegisterTemplate("broken", `{{ .Title`); err == nil {
+		t.Errorf("expected an error for an invalid template")
+	}
+	if err := r.RegisterComponent("broken", `{{ .Name`); err == nil {
+		t.Errorf("expected an error for an invalid component template")
+	}
+}
+
+func TestRendererRegisterComponentKeepsSchema(t *testing.T) {
+	r := NewRenderer()
+	if err := r.RegisterComponent("table", `<table id="{{ .Name }}"></table>`); err != nil {
+		t.Fatalf("failed to register component: %v", err)
+	}
+	if err := r.RegisterComponent("banner", `<marquee>{{ .Name }}</marquee>`); err != nil {
+		t.Fatalf("failed to register component: %v", err)
+	}
+
+	page := newTestPage()
+	page.Spec.Components = []v1alpha1.Component{
+		{Name: "metrics", Type: "table", Config: map[string]interface{}{"pageSize": 10}},
+		{Name: "news", Type: "banner", Config: map[string]interface{}{"speed": "fast"}},
+	}
+	errs := r.Validate(page)
+	if len(errs) != 1 || errs[0].Field != "spec.components[0].config.columns" {
+		t.Errorf("expected only the missing table columns to be reported, got %v", errs)
+	}
+}
+
+func TestRendererFallsBackToDefaults(t *testing.T) {
+	page := newTestPage()
+	page.Spec.Template = "does-not-exist"
+	page.Spec.Theme = ""
+	html, err := NewRenderer().RenderString(page)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(html, "theme-light") || !strings.Contains(html, "<main>") {
+		t.Errorf("expected default template and theme, got\n%s", html)
+	}
+}
+
+// newV1beta1TestPage returns newTestPage with v1beta1 layout, order and theme
+// overrides, stored the way v1alpha1 clients see them
+func newV1beta1TestPage(t *testing.T, spec func(*v1beta1.FrontendPageSpec)) *v1alpha1.FrontendPage {
+	t.Helper()
+	hub := &v1beta1.FrontendPage{}
+	if err := newTestPage().ConvertTo(hub); err != nil {
+		t.Fatalf("failed to convert page to v1beta1: %v", err)
+	}
+	spec(&hub.Spec)
+	page := &v1alpha1.FrontendPage{}
+	if err := page.ConvertFrom(hub); err != nil {
+		t.Fatalf("failed to convert page from v1beta1: %v", err)
+	}
+	return page
+}
+
+func TestRendererOrdersComponents(t *testing.T) {
+	page := newV1beta1TestPage(t, func(spec *v1beta1.FrontendPageSpec) {
+		spec.Components[0].Order = 2 // metrics
+		spec.Components[1].Order = 1 // actions
+		spec.Components[2].Order = 1 // custom keeps its place after actions
+	})
+	html, err := NewRenderer().RenderString(page)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	actions, custom, metrics := strings.Index(html, `data-action="refresh"`), strings.Index(html, `id="custom"`), strings.Index(html, `<th>Name</th>`)
+	if actions < 0 || custom < 0 || metrics < 0 || !(actions < custom && custom < metrics) {
+		t.Errorf("expected components in the order actions, custom, metrics\n%s", html)
+	}
+}
+
+func TestRendererAppliesLayoutAndThemeOverrides(t *testing.T) {
+	page := newV1beta1TestPage(t, func(spec *v1beta1.FrontendPageSpec) {
+		spec.Layout = v1beta1.Layout{Type: v1beta1.LayoutGrid, Columns: 3}
+		spec.Theme.PrimaryColor = "#ff6600"
+		spec.Theme.FontFamily = `"Inter", sans-serif`
+		spec.Theme.BackgroundColor = "red;}</style><script>alert(1)</script>"
+	})
+	html, err := NewRenderer().RenderString(page)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, want := range []string{
+		"grid-template-columns: repeat(3, minmax(0, 1fr))",
+		"h1 { color: #ff6600; }",
+		`font-family: "Inter", sans-serif;`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected rendered page to contain %q\n%s", want, html)
+		}
+	}
+	if strings.Contains(html, "alert(1)") || strings.Contains(html, "background: red") {
+		t.Errorf("expected an invalid background color to be ignored\n%s", html)
+	}
+
+	page = newV1beta1TestPage(t, func(spec *v1beta1.FrontendPageSpec) {
+		spec.Layout = v1beta1.Layout{Type: v1beta1.LayoutStack}
+	})
+	if html, err = NewRenderer().RenderString(page); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(html, "main, main.grid { display: block; }") {
+		t.Errorf("expected the stack layout to override the dashboard grid\n%s", html)
+	}
+}
@@ -0,0 +1,66 @@
+package render
+
+// builtinTemplates are the page templates registered by NewRenderer
+var builtinTemplates = map[string]string{
+	DefaultTemplate: `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>{{ .ThemeCSS }}</style>
+</head>
+<body class="template-{{ .Template }} theme-{{ .Theme }}">
+<main>
+<h1>{{ .Title }}</h1>
+{{- range .Components }}
+{{ . }}
+{{- end }}
+</main>
+</body>
+</html>
+`,
+	"dashboard": `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>{{ .ThemeCSS }}
+.grid { display: grid; grid-template-columns: repeat(auto-fill, minmax(20rem, 1fr)); gap: 1rem; }</style>
+</head>
+<body class="template-dashboard theme-{{ .Theme }}">
+<header><h1>{{ .Title }}</h1></header>
+<main class="grid">
+{{- range .Components }}
+{{ . }}
+{{- end }}
+</main>
+</body>
+</html>
+`,
+	"landing": `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>{{ .ThemeCSS }}
+.hero { text-align: center; padding: 4rem 1rem; }</style>
+</head>
+<body class="template-landing theme-{{ .Theme }}">
+<section class="hero"><h1>{{ .Title }}</h1></section>
+<main>
+{{- range .Components }}
+{{ . }}
+{{- end }}
+</main>
+</body>
+</html>
+`,
+}
+
+// builtinThemes are the theme stylesheets registered by NewRenderer
+var builtinThemes = map[string]string{
+	DefaultTheme: `body { font-family: sans-serif; margin: 0 auto; max-width: 72rem; background: #ffffff; color: #1f2328; }
+.component { border: 1px solid #d0d7de; border-radius: 6px; padding: 1rem; margin: 1rem 0; }`,
+	"dark": `body { font-family: sans-serif; margin: 0 auto; max-width: 72rem; background: #0d1117; color: #e6edf3; }
+.component { border: 1px solid #30363d; border-radius: 6px; padding: 1rem; margin: 1rem 0; }`,
+}
@@ -0,0 +1,43 @@
+package render
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+)
+
+// Validate checks that the FrontendPage spec can be rendered: the title is set,
+// the template is registered, component names are unique and every component
+// has a registered type with a valid config
+func (r *Renderer) Validate(page *v1alpha1.FrontendPage) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if strings.TrimSpace(page.Spec.Title) == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("title"), "title cannot be empty"))
+	}
+
+	if !r.HasTemplate(page.Spec.Template) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("template"), page.Spec.Template, r.Templates()))
+	}
+
+	componentsPath := specPath.Child("components")
+	names := sets.New[string]()
+	for i, component := range page.Spec.Components {
+		namePath := componentsPath.Index(i).Child("name")
+		switch {
+		case component.Name == "":
+			allErrs = append(allErrs, field.Required(namePath, "component name cannot be empty"))
+		case names.Has(component.Name):
+			allErrs = append(allErrs, field.Duplicate(namePath, component.Name))
+		default:
+			names.Insert(component.Name)
+		}
+	}
+	allErrs = append(allErrs, r.registry.Validate(page.Spec.Components, componentsPath)...)
+
+	return allErrs
+}
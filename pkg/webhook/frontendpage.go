@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/api/v1beta1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/render"
+)
+
+//+kubebuilder:webhook:path=/mutate-frontend-thegostev-com-v1alpha1-frontendpage,mutating=true,failurePolicy=fail,sideEffects=None,groups=frontend.thegostev.com,resources=frontendpages,verbs=create;update,versions=v1alpha1,name=mfrontendpage.frontend.thegostev.com,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-frontend-thegostev-com-v1alpha1-frontendpage,mutating=false,failurePolicy=fail,sideEffects=None,groups=frontend.thegostev.com,resources=frontendpages,verbs=create;update,versions=v1alpha1,name=vfrontendpage.frontend.thegostev.com,admissionReviewVersions=v1
+
+// FrontendPageWebhook defaults and validates FrontendPages at admission time
+type FrontendPageWebhook struct {
+	// Renderer provides the registered templates and component types specs are validated against
+	Renderer *render.Renderer
+}
+
+var _ admission.CustomDefaulter = &FrontendPageWebhook{}
+var _ admission.CustomValidator = &FrontendPageWebhook{}
+
+// Default sets default values on a FrontendPage
+func (w *FrontendPageWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	page, ok := obj.(*v1alpha1.FrontendPage)
+	if !ok {
+		return fmt.Errorf("expected a FrontendPage but got %T", obj)
+	}
+	if page.Spec.Theme == "" {
+		page.Spec.Theme = render.DefaultTheme
+	}
+	return nil
+}
+
+// ValidateCreate validates a FrontendPage on creation
+func (w *FrontendPageWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, w.validate(obj)
+}
+
+// ValidateUpdate validates a FrontendPage on update. Updates of pages being
+// deleted and updates leaving the spec alone, such as adding or removing the
+// finalizer, are admitted without validation, so pages that became invalid
+// (created before the webhook, or using a template or component type since
+// dropped from the registry) can still be finalized and deleted.
+func (w *FrontendPageWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldPage, ok := oldObj.(*v1alpha1.FrontendPage)
+	if !ok {
+		return nil, fmt.Errorf("expected a FrontendPage but got %T", oldObj)
+	}
+	newPage, ok := newObj.(*v1alpha1.FrontendPage)
+	if !ok {
+		return nil, fmt.Errorf("expected a FrontendPage but got %T", newObj)
+	}
+	if newPage.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	// newObj went through Default, so compare with the defaulted old spec
+	oldPage = oldPage.DeepCopy()
+	if err := w.Default(ctx, oldPage); err != nil {
+		return nil, err
+	}
+	if equality.Semantic.DeepEqual(oldPage.Spec, newPage.Spec) {
+		return nil, nil
+	}
+	return nil, w.validate(newObj)
+}
+
+// ValidateDelete allows all deletions
+func (w *FrontendPageWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate returns an Invalid API error carrying field-path causes for every problem found
+func (w *FrontendPageWebhook) validate(obj runtime.Object) error {
+	page, ok := obj.(*v1alpha1.FrontendPage)
+	if !ok {
+		return fmt.Errorf("expected a FrontendPage but got %T", obj)
+	}
+	if errs := w.Renderer.Validate(page); len(errs) > 0 {
+		return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("FrontendPage").GroupKind(), page.Name, errs)
+	}
+	return nil
+}
+
+// SetupFrontendPageWebhook registers the FrontendPage defaulting and validating webhooks
+// with the manager's webhook server
+func SetupFrontendPageWebhook(mgr manager.Manager, renderer *render.Renderer) error {
+	w := &FrontendPageWebhook{Renderer: renderer}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.FrontendPage{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+// SetupFrontendPageConversionWebhook serves the FrontendPage conversion webhook on /convert.
+// The CRD stores v1beta1, so the API server needs it to serve v1alpha1.
+func SetupFrontendPageConversionWebhook(mgr manager.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1beta1.FrontendPage{}).
+		Complete()
+}
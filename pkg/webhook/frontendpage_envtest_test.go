@@ -0,0 +1,285 @@
+//go:build integration
+
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/yaml"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/api/v1beta1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/k8s"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/render"
+)
+
+// servedFrontendPageCRD returns the generated FrontendPage CRD with every version
+// served, as installed by config/crd/conversion; envtest points its conversion
+// webhook at the test manager
+func servedFrontendPageCRD(t *testing.T) *apiextensionsv1.CustomResourceDefinition {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "..", "config", "crd", "bases", "frontend.thegostev.com_frontendpages.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read CRD: %v", err)
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(data, crd); err != nil {
+		t.Fatalf("failed to decode CRD: %v", err)
+	}
+	for i := range crd.Spec.Versions {
+		crd.Spec.Versions[i].Served = true
+	}
+	return crd
+}
+
+// startAdmissionEnv starts envtest with the CRDs and webhook configurations
+// installed and a manager serving the FrontendPage webhooks until the test ends
+func startAdmissionEnv(t *testing.T) (context.Context, *rest.Config, client.Client) {
+	t.Helper()
+	testEnv := &envtest.Environment{
+		CRDs: []*apiextensionsv1.CustomResourceDefinition{servedFrontendPageCRD(t)},
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: []string{filepath.Join("..", "..", "config", "webhook")},
+		},
+		Scheme: k8s.NewScheme(),
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		t.Fatalf("failed to start envtest: %v", err)
+	}
+	t.Cleanup(func() { _ = testEnv.Stop() })
+
+	opts := testEnv.WebhookInstallOptions
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  k8s.NewScheme(),
+		Metrics: metricsserver.Options{BindAddress: "0"},
+		WebhookServer: ctrlwebhook.NewServer(ctrlwebhook.Options{
+			Host:    opts.LocalServingHost,
+			Port:    opts.LocalServingPort,
+			CertDir: opts.LocalServingCertDir,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	if err := SetupFrontendPageWebhook(mgr, render.NewRenderer()); err != nil {
+		t.Fatalf("failed to set up webhook: %v", err)
+	}
+	if err := SetupFrontendPageConversionWebhook(mgr); err != nil {
+		t.Fatalf("failed to set up conversion webhook: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = mgr.Start(ctx) }()
+
+	// Wait for the webhook server to accept connections
+	addr := net.JoinHostPort(opts.LocalServingHost, fmt.Sprint(opts.LocalServingPort))
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: time.Second}, "tcp", addr, &tls.Config{InsecureSkipVerify: true})
+		if err == nil {
+			_ = conn.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("webhook server did not start: %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	k8sClient, err := client.New(cfg, client.Options{Scheme: k8s.NewScheme()})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return ctx, cfg, k8sClient
+}
+
+func TestFrontendPageAdmission(t *testing.T) {
+	ctx, _, k8sClient := startAdmissionEnv(t)
+
+	// A valid page without a theme is admitted and defaulted
+	page := &v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "valid", Namespace: "default"},
+		Spec: v1alpha1.FrontendPageSpec{
+			Title:      "Valid",
+			Template:   "dashboard",
+			Components: []v1alpha1.Component{{Name: "trend", Type: "chart"}},
+		},
+	}
+	if err := k8sClient.Create(ctx, page); err != nil {
+		t.Fatalf("failed to create valid page: %v", err)
+	}
+	if page.Spec.Theme != render.DefaultTheme {
+		t.Errorf("expected theme to be defaulted to %q, got %q", render.DefaultTheme, page.Spec.Theme)
+	}
+
+	// An invalid page is rejected with field-path errors
+	invalid := &v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "default"},
+		Spec: v1alpha1.FrontendPageSpec{
+			Title:    "",
+			Template: "blog",
+			Components: []v1alpha1.Component{
+				{Name: "trend", Type: "chart"},
+				{Name: "trend", Type: "carousel"},
+			},
+		},
+	}
+	err := k8sClient.Create(ctx, invalid)
+	if !apierrors.IsInvalid(err) {
+		t.Fatalf("expected an Invalid error, got %v", err)
+	}
+	for _, want := range []string{"spec.title", "spec.template", "spec.components[1].name", "spec.components[1].type"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to reference %q, got %v", want, err)
+		}
+	}
+
+	// v1alpha1 pages are served as v1beta1 with typed component configs
+	converted := &v1beta1.FrontendPage{}
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(page), converted); err != nil {
+		t.Fatalf("failed to get page as v1beta1: %v", err)
+	}
+	if converted.Spec.Theme.Name != render.DefaultTheme {
+		t.Errorf("expected v1beta1 theme name %q, got %+v", render.DefaultTheme, converted.Spec.Theme)
+	}
+
+	// v1beta1-only fields survive an update through v1alpha1
+	converted.Spec.Layout = v1beta1.Layout{Type: v1beta1.LayoutGrid, Columns: 2}
+	converted.Spec.Components = append(converted.Spec.Components, v1beta1.Component{
+		Name:  "table",
+		Type:  "table",
+		Order: -1,
+		Table: &v1beta1.TableConfig{Columns: []string{"Name"}},
+	})
+	if err := k8sClient.Update(ctx, converted); err != nil {
+		t.Fatalf("failed to update page as v1beta1: %v", err)
+	}
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(page), page); err != nil {
+		t.Fatalf("failed to get page as v1alpha1: %v", err)
+	}
+	if cols := page.Spec.Components[1].Config["columns"]; fmt.Sprint(cols) != "[Name]" {
+		t.Errorf("expected table columns in the v1alpha1 config, got %v", page.Spec.Components[1].Config)
+	}
+	page.Spec.Title = "Renamed"
+	if err := k8sClient.Update(ctx, page); err != nil {
+		t.Fatalf("failed to update page as v1alpha1: %v", err)
+	}
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(page), converted); err != nil {
+		t.Fatalf("failed to get page as v1beta1: %v", err)
+	}
+	if converted.Spec.Title != "Renamed" || converted.Spec.Layout.Columns != 2 || converted.Spec.Components[1].Order != -1 {
+		t.Errorf("expected v1beta1 fields to survive a v1alpha1 update, got %+v", converted.Spec)
+	}
+}
+
+func TestInvalidFrontendPageCanBeDeleted(t *testing.T) {
+	ctx, cfg, k8sClient := startAdmissionEnv(t)
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		t.Fatalf("failed to create clientset: %v", err)
+	}
+
+	// Turn the webhooks off, as before they were deployed
+	webhooks := clientset.AdmissionregistrationV1()
+	mutating, err := webhooks.MutatingWebhookConfigurations().Get(ctx, "mutating-webhook-configuration", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get mutating webhook configuration: %v", err)
+	}
+	validating, err := webhooks.ValidatingWebhookConfigurations().Get(ctx, "validating-webhook-configuration", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get validating webhook configuration: %v", err)
+	}
+	if err := webhooks.MutatingWebhookConfigurations().Delete(ctx, mutating.Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete mutating webhook configuration: %v", err)
+	}
+	if err := webhooks.ValidatingWebhookConfigurations().Delete(ctx, validating.Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete validating webhook configuration: %v", err)
+	}
+
+	// An invalid page gets in while the webhooks are off; the API server may
+	// still call them for a moment after the deletion
+	page := &v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "default"},
+		Spec:       v1alpha1.FrontendPageSpec{Title: "Legacy", Template: "blog"},
+	}
+	eventually(t, func() error { return k8sClient.Create(ctx, page) })
+
+	// Turn the webhooks back on and wait until they reject spec changes
+	mutating.ResourceVersion, validating.ResourceVersion = "", ""
+	if _, err := webhooks.MutatingWebhookConfigurations().Create(ctx, mutating, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to recreate mutating webhook configuration: %v", err)
+	}
+	if _, err := webhooks.ValidatingWebhookConfigurations().Create(ctx, validating, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to recreate validating webhook configuration: %v", err)
+	}
+	eventually(t, func() error {
+		renamed := page.DeepCopy()
+		renamed.Spec.Title = "Renamed"
+		if err := k8sClient.Update(ctx, renamed); !apierrors.IsInvalid(err) {
+			return fmt.Errorf("expected the webhook to reject a spec change, got %v", err)
+		}
+		return nil
+	})
+
+	// The controller adds the finalizer, the page is deleted and the
+	// controller removes the finalizer again
+	original := page.DeepCopy()
+	page.Finalizers = []string{"frontend.thegostev.com/finalizer"}
+	if err := k8sClient.Patch(ctx, page, client.MergeFrom(original)); err != nil {
+		t.Fatalf("failed to add finalizer to invalid page: %v", err)
+	}
+	if err := k8sClient.Delete(ctx, page); err != nil {
+		t.Fatalf("failed to delete invalid page: %v", err)
+	}
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(page), page); err != nil {
+		t.Fatalf("failed to get deleted page: %v", err)
+	}
+	original = page.DeepCopy()
+	page.Finalizers = nil
+	if err := k8sClient.Patch(ctx, page, client.MergeFrom(original)); err != nil {
+		t.Fatalf("failed to remove finalizer from deleted invalid page: %v", err)
+	}
+	eventually(t, func() error {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(page), &v1alpha1.FrontendPage{})
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("expected the page to be gone, got %v", err)
+		}
+		return nil
+	})
+}
+
+// eventually retries f for up to 10 seconds until it succeeds
+func eventually(t *testing.T, f func() error) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		err := f()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal(err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/thegostev/go-kubernetes-controllers/api/v1alpha1"
+	"github.com/thegostev/go-kubernetes-controllers/pkg/render"
+)
+
+func TestFrontendPageWebhookDefault(t *testing.T) {
+	w := &FrontendPageWebhook{Renderer: render.NewRenderer()}
+	page := &v1alpha1.FrontendPage{Spec: v1alpha1.FrontendPageSpec{Title: "Home", Template: "landing"}}
+	if err := w.Default(context.Background(), page); err != nil {
+		t.Fatalf("default failed: %v", err)
+	}
+	if page.Spec.Theme != render.DefaultTheme {
+		t.Errorf("expected theme %q, got %q", render.DefaultTheme, page.Spec.Theme)
+	}
+
+	page.Spec.Theme = "dark"
+	if err := w.Default(context.Background(), page); err != nil {
+		t.Fatalf("default failed: %v", err)
+	}
+	if page.Spec.Theme != "dark" {
+		t.Errorf("expected explicit theme to be kept, got %q", page.Spec.Theme)
+	}
+}
+
+func TestFrontendPageWebhookValidate(t *testing.T) {
+	w := &FrontendPageWebhook{Renderer: render.NewRenderer()}
+	valid := v1alpha1.FrontendPageSpec{
+		Title:    "Dashboard",
+		Template: "dashboard",
+		Components: []v1alpha1.Component{
+			{Name: "metrics", Type: "table", Config: map[string]interface{}{"columns": []interface{}{"Name"}}},
+			{Name: "trend", Type: "chart"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		mutate     func(spec *v1alpha1.FrontendPageSpec)
+		wantFields []string
+	}{
+		{name: "valid", mutate: func(spec *v1alpha1.FrontendPageSpec) {}},
+		{
+			name:       "empty title",
+			mutate:     func(spec *v1alpha1.FrontendPageSpec) { spec.Title = "  " },
+			wantFields: []string{"spec.title"},
+		},
+		{
+			name:       "unknown template",
+			mutate:     func(spec *v1alpha1.FrontendPageSpec) { spec.Template = "blog" },
+			wantFields: []string{"spec.template"},
+		},
+		{
+			name:       "duplicate component name",
+			mutate:     func(spec *v1alpha1.FrontendPageSpec) { spec.Components[1].Name = "metrics" },
+			wantFields: []string{"spec.components[1].name"},
+		},
+		{
+			name:       "unknown component type",
+			mutate:     func(spec *v1alpha1.FrontendPageSpec) { spec.Components[1].Type = "carousel" },
+			wantFields: []string{"spec.components[1].type"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &v1alpha1.FrontendPage{ObjectMeta: metav1.ObjectMeta{Name: "page"}}
+			valid.DeepCopyInto(&page.Spec)
+			tt.mutate(&page.Spec)
+
+			_, err := w.ValidateCreate(context.Background(), page)
+			if len(tt.wantFields) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if !apierrors.IsInvalid(err) {
+				t.Fatalf("expected an Invalid error, got %v", err)
+			}
+			causes := err.(apierrors.APIStatus).Status().Details.Causes
+			if len(causes) != len(tt.wantFields) {
+				t.Fatalf("expected %d causes, got %v", len(tt.wantFields), causes)
+			}
+			for i, want := range tt.wantFields {
+				if causes[i].Field != want {
+					t.Errorf("expected cause for field %q, got %q", want, causes[i].Field)
+				}
+			}
+		})
+	}
+}
+
+func TestFrontendPageWebhookValidateUpdate(t *testing.T) {
+	w := &FrontendPageWebhook{Renderer: render.NewRenderer()}
+	// Created before the webhook: invalid and never defaulted
+	invalid := &v1alpha1.FrontendPage{
+		ObjectMeta: metav1.ObjectMeta{Name: "page"},
+		Spec:       v1alpha1.FrontendPageSpec{Title: "Blog", Template: "blog"},
+	}
+
+	// The webhook defaults the theme of every update
+	withFinalizer := invalid.DeepCopy()
+	withFinalizer.Finalizers = []string{"frontend.thegostev.com/finalizer"}
+	withFinalizer.Spec.Theme = render.DefaultTheme
+	if _, err := w.ValidateUpdate(context.Background(), invalid, withFinalizer); err != nil {
+		t.Errorf("expected an update leaving the spec alone to be admitted, got %v", err)
+	}
+
+	deleting := withFinalizer.DeepCopy()
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	deleting.Finalizers = nil
+	if _, err := w.ValidateUpdate(context.Background(), withFinalizer, deleting); err != nil {
+		t.Errorf("expected the finalizer removal of a deleted page to be admitted, got %v", err)
+	}
+
+	changed := withFinalizer.DeepCopy()
+	changed.Spec.Title = "Renamed"
+	if _, err := w.ValidateUpdate(context.Background(), withFinalizer, changed); !apierrors.IsInvalid(err) {
+		t.Errorf("expected a spec change of an invalid page to be rejected, got %v", err)
+	}
+}